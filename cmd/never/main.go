@@ -18,6 +18,6 @@ func main() {
 	ctx := context.Background()
 
 	if err := app.Run(ctx, Version, os.Args[1:], os.Stdout, os.Stderr); err != nil {
-		os.Exit(1)
+		os.Exit(app.ExitCode(err))
 	}
 }
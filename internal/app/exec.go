@@ -0,0 +1,31 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// ErrEmptyCommand is returned when exec is requested without a command.
+var ErrEmptyCommand = errors.New("no command to execute")
+
+// execCommand replaces the current process with command, keeping the PID and environment.
+// It only returns when the command cannot be started.
+func execCommand(command []string) error {
+	if len(command) == 0 {
+		return ErrEmptyCommand
+	}
+
+	path, err := exec.LookPath(command[0])
+	if err != nil {
+		return fmt.Errorf("failed to find command %q: %w", command[0], err)
+	}
+
+	if err := syscall.Exec(path, command, os.Environ()); err != nil {
+		return fmt.Errorf("failed to execute command %q: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/containeroo/never/internal/exitcode"
+	"github.com/containeroo/never/internal/factory"
+	"github.com/containeroo/never/internal/runner"
+	"github.com/containeroo/never/internal/wait"
+)
+
+// ErrInterrupted is returned when never is stopped before all targets are ready.
+var ErrInterrupted = errors.New("interrupted before all targets were ready")
+
+// configError marks an invalid configuration without changing the error message.
+type configError struct {
+	err error
+}
+
+// Error implements error.
+func (e *configError) Error() string { return e.err.Error() }
+
+// Unwrap returns the underlying error.
+func (e *configError) Unwrap() error { return e.err }
+
+// buildError marks a BuildCheckers error as invalid configuration unless a checker could not be created.
+func buildError(err error) error {
+	var checkerErr *factory.CheckerError
+	if errors.As(err, &checkerErr) {
+		return err
+	}
+
+	return &configError{err: err}
+}
+
+// ExitCode maps an error returned by Run to the documented process exit code.
+func ExitCode(err error) int {
+	var cfgErr *configError
+	var checkerErr *factory.CheckerError
+
+	switch {
+	case err == nil:
+		return exitcode.OK
+	case errors.As(err, &cfgErr), errors.Is(err, runner.ErrNoCheckers):
+		return exitcode.Config
+	case errors.As(err, &checkerErr):
+		return exitcode.CheckerSetup
+	case errors.Is(err, wait.ErrMaxAttemptsExceeded), errors.Is(err, wait.ErrPermanentFailure), errors.Is(err, runner.ErrNotReady):
+		return exitcode.NotReady
+	case errors.Is(err, context.DeadlineExceeded):
+		return exitcode.Deadline
+	case errors.Is(err, ErrInterrupted):
+		return exitcode.Interrupted
+	default:
+		return exitcode.Failure
+	}
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/containeroo/never/internal/checker"
+	"github.com/containeroo/never/internal/exitcode"
+	"github.com/containeroo/never/internal/factory"
+	"github.com/containeroo/never/internal/runner"
+	"github.com/containeroo/never/internal/testutils"
+	"github.com/containeroo/never/internal/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExitCode verifies errors are mapped to the documented exit codes.
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: exitcode.OK},
+		{name: "unknown", err: errors.New("boom"), want: exitcode.Failure},
+		{name: "config", err: &configError{err: errors.New("unknown flag --invalid")}, want: exitcode.Config},
+		{name: "no checkers", err: runner.ErrNoCheckers, want: exitcode.Config},
+		{name: "checker setup", err: &factory.CheckerError{Type: checker.ICMP, Err: errors.New("unresolvable")}, want: exitcode.CheckerSetup},
+		{name: "max attempts", err: fmt.Errorf("checker 'db' failed: %w after 3 attempts: %w", wait.ErrMaxAttemptsExceeded, context.DeadlineExceeded), want: exitcode.NotReady},
+		{name: "once", err: fmt.Errorf("%w: 1 of 2 targets failed", runner.ErrNotReady), want: exitcode.NotReady},
+		{name: "deadline", err: fmt.Errorf("checker 'db' failed: %w", context.DeadlineExceeded), want: exitcode.Deadline},
+		{name: "interrupted", err: fmt.Errorf("%w: received signal: terminated", ErrInterrupted), want: exitcode.Interrupted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
+
+// TestRunExitCode verifies errors returned by Run map to the expected exit codes.
+func TestRunExitCode(t *testing.T) {
+	t.Parallel()
+
+	run := func(ctx context.Context, args ...string) error {
+		var stdOut, stdErr bytes.Buffer
+		return Run(ctx, version, args, &stdOut, &stdErr)
+	}
+
+	t.Run("parse error", func(t *testing.T) {
+		t.Parallel()
+
+		err := run(context.Background(), "--invalid")
+		assert.EqualError(t, err, "unknown flag --invalid")
+		assert.Equal(t, exitcode.Config, ExitCode(err))
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		t.Parallel()
+
+		err := run(context.Background(), "--http.web.address=http://localhost", "--http.web.header=invalid")
+		assert.Equal(t, exitcode.Config, ExitCode(err))
+	})
+
+	t.Run("ICMP address of wrong family", func(t *testing.T) {
+		t.Parallel()
+
+		err := run(context.Background(), "--icmp.gw.address=127.0.0.1", "--icmp.gw.ip-family=6")
+		assert.Equal(t, exitcode.CheckerSetup, ExitCode(err))
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		err := run(context.Background(), "--tcp.down.address="+testutils.LocalTCPAddr(t), "--tcp.down.interval=10ms", "--max-attempts=1")
+		assert.Equal(t, exitcode.NotReady, ExitCode(err))
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		err := run(ctx, "--tcp.down.address="+testutils.LocalTCPAddr(t), "--tcp.down.interval=10ms")
+		assert.Equal(t, exitcode.Deadline, ExitCode(err))
+	})
+
+	t.Run("interrupted", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancelCause(context.Background())
+		time.AfterFunc(100*time.Millisecond, func() { cancel(errors.New("received signal: terminated")) })
+
+		err := run(ctx, "--tcp.down.address="+testutils.LocalTCPAddr(t), "--tcp.down.interval=10ms")
+		require.ErrorIs(t, err, ErrInterrupted)
+		assert.EqualError(t, err, "interrupted before all targets were ready: received signal: terminated")
+		assert.Equal(t, exitcode.Interrupted, ExitCode(err))
+	})
+}
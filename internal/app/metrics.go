@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/containeroo/never/internal/cli"
+	"github.com/containeroo/never/internal/metrics"
+
+	"github.com/containeroo/httpgrace/server"
+)
+
+// newMetricsRegistry returns a registry when metrics are served or pushed, and nil otherwise.
+func newMetricsRegistry(cfg *cli.Config) *metrics.Registry {
+	if cfg.MetricsAddress == "" && cfg.MetricsPushURL == "" {
+		return nil
+	}
+
+	return metrics.NewRegistry()
+}
+
+// serveMetrics serves the registry on /metrics until ctx is canceled.
+func serveMetrics(ctx context.Context, addr string, registry *metrics.Registry, logger *slog.Logger) error {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", registry.Handler())
+
+	return server.Run(ctx, addr, mux, logger)
+}
+
+// startMetricsServer serves metrics in the background when an address is configured.
+// The returned function stops the server and waits for it to exit.
+func startMetricsServer(ctx context.Context, cfg *cli.Config, registry *metrics.Registry, logger *slog.Logger) func() {
+	if cfg.MetricsAddress == "" {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		if err := serveMetrics(ctx, cfg.MetricsAddress, registry, logger); err != nil {
+			logger.Error("failed to serve metrics", "err", err)
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// pushMetrics pushes the registry when a push URL is configured. Failures are logged only.
+func pushMetrics(ctx context.Context, cfg *cli.Config, registry *metrics.Registry, logger *slog.Logger) {
+	if cfg.MetricsPushURL == "" {
+		return
+	}
+
+	// Push even when the run was interrupted by a signal.
+	if err := registry.Push(context.WithoutCancel(ctx), cfg.MetricsPushURL, cfg.MetricsPushJob); err != nil {
+		logger.Warn("failed to push metrics", "err", err)
+	}
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/containeroo/never/internal/cli"
+	"github.com/containeroo/never/internal/factory"
+	"github.com/containeroo/never/internal/metrics"
+	"github.com/containeroo/never/internal/monitor"
+
+	"github.com/containeroo/httpgrace/server"
+	"golang.org/x/sync/errgroup"
+)
+
+// runMonitor keeps checking all targets and serves their state until ctx is canceled.
+func runMonitor(
+	ctx context.Context,
+	cfg *cli.Config,
+	checkers []factory.CheckerWithInterval,
+	registry *metrics.Registry,
+	logger *slog.Logger,
+) error {
+	mon := monitor.New(
+		checkers,
+		monitor.WithSuccessThreshold(cfg.SuccessThreshold),
+		monitor.WithFailureThreshold(cfg.FailureThreshold),
+		monitor.WithMetrics(registry),
+	)
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		return server.Run(ctx, cfg.ListenAddress, mon.Handler(), logger)
+	})
+	if cfg.MetricsAddress != "" {
+		eg.Go(func() error {
+			return serveMetrics(ctx, cfg.MetricsAddress, registry, logger)
+		})
+	}
+	eg.Go(func() error {
+		return mon.Run(ctx, logger)
+	})
+
+	return eg.Wait()
+}
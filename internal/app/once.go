@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/containeroo/never/internal/factory"
+	"github.com/containeroo/never/internal/metrics"
+	"github.com/containeroo/never/internal/report"
+	"github.com/containeroo/never/internal/runner"
+)
+
+// runOnce checks every target once, prints one result line per target and
+// records the results in the metrics registry and the report.
+func runOnce(ctx context.Context, checkers []factory.CheckerWithInterval, registry *metrics.Registry, rep *report.Report, stdOut io.Writer) error {
+	results, err := runner.CheckOnce(ctx, checkers)
+	for _, r := range results {
+		_, _ = fmt.Fprintln(stdOut, formatResult(r))
+
+		state := report.StateReady
+		if r.Err != nil {
+			state = report.StateFailed
+		}
+		registry.SetReady(r.Checker.Name(), r.Checker.Type(), r.Err == nil)
+		rep.Attempt(r.Checker, r.Duration, r.Err)
+		rep.Finish(r.Checker, state, r.Duration)
+	}
+
+	return err
+}
+
+// formatResult renders a check result like "✓ web HTTP http://web:8080 1.2ms".
+func formatResult(r runner.Result) string {
+	mark := "✓"
+	if r.Err != nil {
+		mark = "✗"
+	}
+
+	line := fmt.Sprintf("%s %s %s %s %s", mark, r.Checker.Name(), r.Checker.Type(), r.Checker.Address(), r.Duration.Truncate(time.Microsecond))
+	if r.Err != nil {
+		line += ": " + r.Err.Error()
+	}
+
+	return line
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/containeroo/never/internal/cli"
+	"github.com/containeroo/never/internal/report"
+)
+
+// newReport returns a report when a report file or termination message is written, and nil otherwise.
+func newReport(cfg *cli.Config, terminationPath string) *report.Report {
+	if cfg.ReportFile == "" && terminationPath == "" {
+		return nil
+	}
+
+	return report.New()
+}
+
+// resolveTerminationMessagePath returns the termination message path to write, or "" when disabled.
+// The Kubernetes default path is only used when it exists, so running outside a pod stays side-effect free.
+func resolveTerminationMessagePath(cfg *cli.Config) string {
+	if cfg.TerminationMessagePath != cli.DefaultTerminationMessagePath {
+		return cfg.TerminationMessagePath
+	}
+	if _, err := os.Stat(cfg.TerminationMessagePath); err != nil {
+		return ""
+	}
+
+	return cfg.TerminationMessagePath
+}
+
+// finishReport completes the report with the run result and writes the configured outputs.
+// Write failures are logged only.
+func finishReport(cfg *cli.Config, terminationPath string, rep *report.Report, err, cause error, logger *slog.Logger) {
+	if rep == nil {
+		return
+	}
+
+	outcome := report.OutcomeReady
+	switch {
+	case cause != nil:
+		outcome = report.OutcomeInterrupted
+	case err != nil:
+		outcome = report.OutcomeFailed
+	}
+	rep.Complete(outcome, err, cause)
+
+	if cfg.ReportFile != "" {
+		if err := rep.WriteFile(cfg.ReportFile); err != nil {
+			logger.Warn("failed to write report", "path", cfg.ReportFile, "err", err)
+		}
+	}
+
+	// Kubernetes only surfaces the message of failed containers.
+	if terminationPath != "" && err != nil {
+		msg := rep.TerminationMessage(report.TerminationMessageLimit)
+		if err := os.WriteFile(terminationPath, []byte(msg), 0o644); err != nil {
+			logger.Warn("failed to write termination message", "path", terminationPath, "err", err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/containeroo/never/internal/factory"
 	"github.com/containeroo/never/internal/logging"
 	"github.com/containeroo/never/internal/runner"
+	"github.com/containeroo/never/internal/wait"
 
 	"github.com/containeroo/httpgrace/server"
 	"github.com/containeroo/tinyflags"
@@ -24,27 +26,77 @@ func Run(ctx context.Context, version string, args []string, stdOut, stdErr io.W
 			return nil
 		}
 		_, _ = fmt.Fprintln(stdErr, err)
-		return err
+		return &configError{err: err}
+	}
+
+	if cfg.Validate {
+		return runValidate(cfg, version, stdOut, stdErr)
 	}
 
 	// Setup logger immediately so startup errors are correctly logged.
 	logger := logging.SetupLogger(cfg.LogFormat, stdOut)
 
+	terminationPath := resolveTerminationMessagePath(cfg)
+	rep := newReport(cfg, terminationPath)
+
 	// Initialize target checkers
 	checkers, err := factory.BuildCheckers(cfg.Targets, cfg.DefaultCheckInterval, version)
 	if err != nil {
 		logger.Error("failed to initialize target checkers", "err", err)
-		return err
+		finishReport(cfg, terminationPath, rep, err, nil, logger)
+		return buildError(err)
+	}
+
+	// Record every check, whichever mode runs it.
+	registry := newMetricsRegistry(cfg)
+	for i, chk := range checkers {
+		checkers[i].Checker = registry.Instrument(chk.Checker)
+		rep.Add(checkers[i].Checker)
+		for _, warning := range chk.Warnings {
+			logger.Warn(warning, "target", chk.Checker.Name())
+		}
 	}
 
 	// Create a signal-aware context that preserves the shutdown cause.
 	ctx, stop := server.SignalContext(ctx)
 	defer stop()
 
+	if cfg.Monitor {
+		err = runMonitor(ctx, cfg, checkers, registry, logger)
+		pushMetrics(ctx, cfg, registry, logger)
+		if cause := context.Cause(ctx); cause != nil {
+			logger.Info("context stopped", "cause", cause)
+		}
+		if err != nil {
+			logger.Error("failed to monitor checkers", "err", err)
+			return err
+		}
+		return nil
+	}
+
 	// Run all checkers.
-	err = runner.RunAll(ctx, checkers, cfg.MaxAttempts, logger)
+	stopMetrics := startMetricsServer(ctx, cfg, registry, logger)
+	if cfg.Once {
+		err = runOnce(ctx, checkers, registry, rep, stdOut)
+	} else {
+		err = runner.RunAll(ctx, checkers, cfg.MaxAttempts, logger, wait.WithMetrics(registry), wait.WithReport(rep))
+	}
+	cause := context.Cause(ctx)
+	if cause != nil && (err == nil || errors.Is(err, runner.ErrNotReady)) {
+		// RunAll treats cancellation as a clean shutdown and --once reports canceled
+		// checks as failed, so both mean the targets may not be ready yet.
+		err = fmt.Errorf("%w: %w", ErrInterrupted, cause)
+	}
+	stopMetrics()
+	pushMetrics(ctx, cfg, registry, logger)
+	finishReport(cfg, terminationPath, rep, err, cause, logger)
+
+	// --once only prints its result lines.
+	if cfg.Once {
+		return err
+	}
 
-	if cause := context.Cause(ctx); cause != nil {
+	if cause != nil {
 		logger.Info("context stopped", "cause", cause)
 	}
 
@@ -53,5 +105,18 @@ func Run(ctx context.Context, version string, args []string, stdOut, stdErr io.W
 		return err
 	}
 
+	if len(cfg.Command) == 0 {
+		return nil
+	}
+
+	// Restore default signal handling so the command receives signals directly.
+	stop()
+
+	logger.Info("executing command", "command", cfg.Command)
+	if err := execCommand(cfg.Command); err != nil {
+		logger.Error("failed to execute command", "err", err)
+		return err
+	}
+
 	return nil
 }
@@ -3,8 +3,13 @@ package app
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -12,7 +17,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/containeroo/never/internal/exitcode"
+	"github.com/containeroo/never/internal/runner"
 	"github.com/containeroo/never/internal/testutils"
+	"github.com/containeroo/never/internal/wait"
 )
 
 // fake version for testing
@@ -159,3 +167,349 @@ func TestRunParseError(t *testing.T) {
 	require.Error(t, err)
 	assert.EqualError(t, err, "unknown flag --invalid")
 }
+
+// TestRunCommandNotExecutedWhenNotReady verifies the command is skipped when readiness fails.
+func TestRunCommandNotExecutedWhenNotReady(t *testing.T) {
+	t.Parallel()
+
+	marker := filepath.Join(t.TempDir(), "marker")
+
+	args := []string{
+		"--tcp.down.address=" + testutils.LocalTCPAddr(t),
+		"--tcp.down.interval=10ms",
+		"--tcp.down.timeout=100ms",
+		"--max-attempts=1",
+		"--",
+		"touch",
+		marker,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var stdOut, stdErr bytes.Buffer
+
+	err := Run(ctx, version, args, &stdOut, &stdErr)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, wait.ErrMaxAttemptsExceeded)
+	assert.NoFileExists(t, marker)
+}
+
+// TestRunCommandNotFound verifies a missing command is reported after targets are ready.
+func TestRunCommandNotFound(t *testing.T) {
+	t.Parallel()
+
+	listener := testutils.ListenLocalTCP(t)
+	defer listener.Close() // nolint:errcheck
+
+	args := []string{
+		"--tcp.up.address=" + listener.Addr().String(),
+		"--",
+		"never-command-that-does-not-exist",
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var stdOut, stdErr bytes.Buffer
+
+	err := Run(ctx, version, args, &stdOut, &stdErr)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, exec.ErrNotFound)
+	assert.Contains(t, stdOut.String(), "failed to execute command")
+}
+
+// TestRunMonitor verifies monitor mode serves readiness until the context is canceled.
+func TestRunMonitor(t *testing.T) {
+	t.Parallel()
+
+	listener := testutils.ListenLocalTCP(t)
+	defer listener.Close() // nolint:errcheck
+
+	listenAddress := testutils.LocalTCPAddr(t)
+	args := []string{
+		"--monitor",
+		"--listen-address=" + listenAddress,
+		"--tcp.up.address=" + listener.Addr().String(),
+		"--tcp.up.interval=50ms",
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var stdOut, stdErr bytes.Buffer
+	done := make(chan error, 1)
+	go func() { done <- Run(ctx, version, args, &stdOut, &stdErr) }()
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get("http://" + listenAddress + "/readyz") // nolint:noctx
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close() // nolint:errcheck
+		return resp.StatusCode == http.StatusOK
+	}, 3*time.Second, 20*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// TestRunPushMetrics verifies metrics are pushed after a one-shot run.
+func TestRunPushMetrics(t *testing.T) {
+	t.Parallel()
+
+	pushed := make(chan string, 1)
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		pushed <- r.Method + " " + r.URL.Path + "\n" + string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer gateway.Close()
+
+	listener := testutils.ListenLocalTCP(t)
+	defer listener.Close() // nolint:errcheck
+
+	args := []string{
+		"--tcp.db.address=" + listener.Addr().String(),
+		"--metrics-push-url=" + gateway.URL,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var stdOut, stdErr bytes.Buffer
+
+	err := Run(ctx, version, args, &stdOut, &stdErr)
+	require.NoError(t, err)
+
+	body := <-pushed
+	assert.True(t, strings.HasPrefix(body, "PUT /metrics/job/never\n"))
+	assert.Contains(t, body, `never_target_ready{target="db",type="TCP"} 1`)
+	assert.Contains(t, body, `never_check_attempts_total{target="db",type="TCP"} 1`)
+}
+
+// TestRunReportFile verifies a report is written when a target fails.
+func TestRunReportFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	args := []string{
+		"--tcp.down.address=" + testutils.LocalTCPAddr(t),
+		"--tcp.down.interval=10ms",
+		"--max-attempts=2",
+		"--report-file=" + path,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var stdOut, stdErr bytes.Buffer
+
+	err := Run(ctx, version, args, &stdOut, &stdErr)
+	require.Error(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var got struct {
+		Outcome string `json:"outcome"`
+		Error   string `json:"error"`
+		Targets []struct {
+			Name     string    `json:"name"`
+			Attempts int       `json:"attempts"`
+			State    string    `json:"state"`
+			Latency  []float64 `json:"latenciesSeconds"`
+		} `json:"targets"`
+	}
+	require.NoError(t, json.Unmarshal(data, &got))
+
+	assert.Equal(t, "failed", got.Outcome)
+	assert.Contains(t, got.Error, "max attempts reached")
+	require.Len(t, got.Targets, 1)
+	assert.Equal(t, "down", got.Targets[0].Name)
+	assert.Equal(t, 2, got.Targets[0].Attempts)
+	assert.Equal(t, "failed", got.Targets[0].State)
+	assert.Len(t, got.Targets[0].Latency, 2)
+}
+
+// TestRunTerminationMessage verifies a failure summary is written to the termination message path.
+func TestRunTerminationMessage(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "termination-log")
+	address := testutils.LocalTCPAddr(t)
+	args := []string{
+		"--tcp.down.address=" + address,
+		"--tcp.down.interval=10ms",
+		"--max-attempts=2",
+		"--termination-message-path=" + path,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var stdOut, stdErr bytes.Buffer
+
+	err := Run(ctx, version, args, &stdOut, &stdErr)
+	require.Error(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "never failed: 1 of 1 targets not ready\n- down (TCP "+address+"): failed after 2 attempts: "))
+}
+
+// TestRunValidate verifies the validate subcommand prints the effective targets without checking them.
+func TestRunValidate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("table", func(t *testing.T) {
+		t.Parallel()
+
+		args := []string{
+			"validate",
+			"--max-attempts=5",
+			"--tcp.db.address=db.invalid:5432",
+			"--tcp.db.backoff=exponential",
+			"--tcp.db.max-interval=30s",
+			"--icmp.gw.address=gw.invalid",
+			"--icmp.gw.interval=1s",
+			"--icmp.gw.max-attempts=-1",
+		}
+
+		var stdOut, stdErr bytes.Buffer
+
+		err := Run(context.Background(), version, args, &stdOut, &stdErr)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(stdOut.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, []string{"NAME", "TYPE", "ADDRESS", "INTERVAL", "MAX", "ATTEMPTS", "BACKOFF", "MAX", "INTERVAL"}, strings.Fields(lines[0]))
+		assert.Equal(t, []string{"db", "TCP", "db.invalid:5432", "2s", "5", "exponential", "30s"}, strings.Fields(lines[1]))
+		assert.Equal(t, []string{"gw", "ICMP", "gw.invalid", "1s", "endless", "linear", "uncapped"}, strings.Fields(lines[2]))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		args := []string{"validate", "--output=json", "--http.web.address=http://example.com", "--default-interval=3s"}
+
+		var stdOut, stdErr bytes.Buffer
+
+		err := Run(context.Background(), version, args, &stdOut, &stdErr)
+		require.NoError(t, err)
+
+		var got []effectiveTarget
+		require.NoError(t, json.Unmarshal(stdOut.Bytes(), &got))
+		require.Len(t, got, 1)
+		assert.Equal(t, "web", got[0].Name)
+		assert.Equal(t, "HTTP", got[0].Type)
+		assert.Equal(t, 3.0, got[0].IntervalSeconds)
+		assert.Equal(t, -1, got[0].MaxAttempts)
+		assert.Equal(t, "linear", got[0].Backoff)
+	})
+
+	t.Run("unresolvable variable", func(t *testing.T) {
+		t.Parallel()
+
+		args := []string{"validate", "--http.web.address=http://example.com", "--http.web.header=Authorization=env:NEVER_TEST_UNSET_VARIABLE"}
+
+		var stdOut, stdErr bytes.Buffer
+
+		err := Run(context.Background(), version, args, &stdOut, &stdErr)
+		require.Error(t, err)
+		assert.Contains(t, stdErr.String(), "invalid configuration:")
+		assert.Empty(t, stdOut.String())
+	})
+
+	t.Run("no targets", func(t *testing.T) {
+		t.Parallel()
+
+		var stdOut, stdErr bytes.Buffer
+
+		err := Run(context.Background(), version, []string{"validate"}, &stdOut, &stdErr)
+		require.ErrorIs(t, err, runner.ErrNoCheckers)
+	})
+}
+
+// TestRunOnce verifies --once checks every target once and prints one line per target.
+func TestRunOnce(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ready", func(t *testing.T) {
+		t.Parallel()
+
+		listener := testutils.ListenLocalTCP(t)
+		defer listener.Close() // nolint:errcheck
+
+		args := []string{"--once", "--tcp.db.address=" + listener.Addr().String()}
+
+		var stdOut, stdErr bytes.Buffer
+
+		err := Run(context.Background(), version, args, &stdOut, &stdErr)
+		require.NoError(t, err)
+		assert.Regexp(t, `^✓ db TCP `+listener.Addr().String()+` \S+\n$`, stdOut.String())
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		t.Parallel()
+
+		addr := testutils.LocalTCPAddr(t)
+		args := []string{"--once", "--tcp.down.address=" + addr, "--tcp.down.interval=1h", "--max-attempts=5"}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+
+		var stdOut, stdErr bytes.Buffer
+
+		err := Run(ctx, version, args, &stdOut, &stdErr)
+		require.ErrorIs(t, err, runner.ErrNotReady)
+		assert.Regexp(t, `^✗ down TCP `+addr+` \S+: .*connection refused\n$`, stdOut.String())
+	})
+
+	t.Run("report file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "report.json")
+		args := []string{"--once", "--tcp.down.address=" + testutils.LocalTCPAddr(t), "--report-file=" + path}
+
+		var stdOut, stdErr bytes.Buffer
+
+		err := Run(context.Background(), version, args, &stdOut, &stdErr)
+		require.ErrorIs(t, err, runner.ErrNotReady)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		var got struct {
+			Outcome string `json:"outcome"`
+			Targets []struct {
+				Attempts int    `json:"attempts"`
+				State    string `json:"state"`
+			} `json:"targets"`
+		}
+		require.NoError(t, json.Unmarshal(data, &got))
+
+		assert.Equal(t, "failed", got.Outcome)
+		require.Len(t, got.Targets, 1)
+		assert.Equal(t, 1, got.Targets[0].Attempts)
+		assert.Equal(t, "failed", got.Targets[0].State)
+	})
+
+	t.Run("interrupted", func(t *testing.T) {
+		t.Parallel()
+
+		listener := testutils.ListenLocalTCP(t)
+		defer listener.Close() // nolint:errcheck
+
+		args := []string{"--once", "--tcp.db.address=" + listener.Addr().String()}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var stdOut, stdErr bytes.Buffer
+
+		err := Run(ctx, version, args, &stdOut, &stdErr)
+		require.ErrorIs(t, err, ErrInterrupted)
+		assert.Equal(t, exitcode.Interrupted, ExitCode(err))
+	})
+}
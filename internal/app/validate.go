@@ -0,0 +1,87 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/containeroo/never/internal/cli"
+	"github.com/containeroo/never/internal/factory"
+	"github.com/containeroo/never/internal/runner"
+	"github.com/containeroo/never/internal/utils"
+)
+
+// effectiveTarget is a target with all defaults applied, as printed by the validate subcommand.
+type effectiveTarget struct {
+	Name               string  `json:"name"`
+	Type               string  `json:"type"`
+	Address            string  `json:"address"`
+	IntervalSeconds    float64 `json:"intervalSeconds"`
+	MaxAttempts        int     `json:"maxAttempts"`
+	Backoff            string  `json:"backoff"`
+	MaxIntervalSeconds float64 `json:"maxIntervalSeconds"`
+
+	interval    time.Duration
+	maxInterval time.Duration
+}
+
+// runValidate builds all checkers and prints the effective targets.
+func runValidate(cfg *cli.Config, version string, stdOut, stdErr io.Writer) error {
+	checkers, err := factory.BuildCheckers(cfg.Targets, cfg.DefaultCheckInterval, version)
+	if err == nil && len(checkers) == 0 {
+		err = runner.ErrNoCheckers
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(stdErr, "invalid configuration:", err)
+		return buildError(err)
+	}
+
+	targets := make([]effectiveTarget, 0, len(checkers))
+	for _, chk := range checkers {
+		for _, warning := range chk.Warnings {
+			_, _ = fmt.Fprintf(stdErr, "warning: %s: %s\n", chk.Checker.Name(), warning)
+		}
+		targets = append(targets, effectiveTarget{
+			Name:               chk.Checker.Name(),
+			Type:               chk.Checker.Type(),
+			Address:            chk.Checker.Address(),
+			IntervalSeconds:    chk.Interval.Seconds(),
+			MaxAttempts:        utils.DefaultIfZero(chk.MaxAttempts, cfg.MaxAttempts),
+			Backoff:            chk.Backoff.String(),
+			MaxIntervalSeconds: chk.MaxInterval.Seconds(),
+			interval:           chk.Interval,
+			maxInterval:        chk.MaxInterval,
+		})
+	}
+
+	if cfg.Output == cli.OutputJSON {
+		enc := json.NewEncoder(stdOut)
+		enc.SetIndent("", "  ")
+		return enc.Encode(targets)
+	}
+
+	return writeTargetTable(stdOut, targets)
+}
+
+// writeTargetTable prints the effective targets as an aligned table.
+func writeTargetTable(w io.Writer, targets []effectiveTarget) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tTYPE\tADDRESS\tINTERVAL\tMAX ATTEMPTS\tBACKOFF\tMAX INTERVAL")
+	for _, t := range targets {
+		attempts := strconv.Itoa(t.MaxAttempts)
+		if t.MaxAttempts < 0 {
+			attempts = "endless"
+		}
+		maxInterval := "uncapped"
+		if t.maxInterval > 0 {
+			maxInterval = t.maxInterval.String()
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			t.Name, t.Type, t.Address, t.interval, attempts, t.Backoff, maxInterval)
+	}
+
+	return tw.Flush()
+}
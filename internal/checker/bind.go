@@ -0,0 +1,94 @@
+package checker
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"syscall"
+)
+
+// sourceBinding is the local address and network interface a checker sends its packets from.
+type sourceBinding struct {
+	address net.IP // address is the local IP. Nil lets the system choose.
+	iface   string // iface is the network interface, such as a Multus secondary network. Empty uses the routing table.
+}
+
+// String returns the binding in a form usable as part of a socket key.
+func (b sourceBinding) String() string {
+	address := ""
+	if b.address != nil {
+		address = b.address.String()
+	}
+
+	return "src=" + address + " dev=" + b.iface
+}
+
+// listenAddress returns the local address to listen on, or an empty string for any address.
+func (b sourceBinding) listenAddress() string {
+	if b.address == nil {
+		return ""
+	}
+
+	return b.address.String()
+}
+
+// apply binds the connections dialer opens on network to the source address and interface.
+func (b sourceBinding) apply(dialer *net.Dialer, network string) {
+	if b.address != nil {
+		dialer.LocalAddr = &net.TCPAddr{IP: b.address}
+		if strings.HasPrefix(network, "udp") {
+			dialer.LocalAddr = &net.UDPAddr{IP: b.address}
+		}
+	}
+	if b.iface != "" {
+		dialer.Control = func(_, _ string, raw syscall.RawConn) error {
+			return bindToDevice(raw, b.iface)
+		}
+	}
+}
+
+// bindPacketConn binds conn to the interface, if any.
+func (b sourceBinding) bindPacketConn(conn net.PacketConn) error {
+	if b.iface == "" {
+		return nil
+	}
+
+	sc, ok := conn.(syscall.Conn)
+	if !ok {
+		return errors.New("socket does not expose its file descriptor")
+	}
+	raw, err := sc.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	return bindToDevice(raw, b.iface)
+}
+
+// WithSourceAddress sets the local IP the checker sends its packets from.
+func WithSourceAddress(ip net.IP) Option {
+	return OptionFunc(func(c Checker) {
+		switch chk := c.(type) {
+		case *HTTPChecker:
+			chk.source.address = ip
+		case *TCPChecker:
+			chk.source.address = ip
+		case *ICMPChecker:
+			chk.source.address = ip
+		}
+	})
+}
+
+// WithInterface sets the network interface the checker sends its packets through. It is only supported on Linux.
+func WithInterface(name string) Option {
+	return OptionFunc(func(c Checker) {
+		switch chk := c.(type) {
+		case *HTTPChecker:
+			chk.source.iface = name
+		case *TCPChecker:
+			chk.source.iface = name
+		case *ICMPChecker:
+			chk.source.iface = name
+		}
+	})
+}
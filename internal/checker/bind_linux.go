@@ -0,0 +1,21 @@
+package checker
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// bindToDevice binds the socket to the network interface iface with SO_BINDTODEVICE.
+func bindToDevice(raw syscall.RawConn, iface string) error {
+	var sockErr error
+	if err := raw.Control(func(fd uintptr) {
+		sockErr = syscall.BindToDevice(int(fd), iface)
+	}); err != nil {
+		return err
+	}
+	if sockErr != nil {
+		return fmt.Errorf("failed to bind to interface %s: %w", iface, sockErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"syscall"
+)
+
+// bindToDevice is only implemented on Linux.
+func bindToDevice(_ syscall.RawConn, iface string) error {
+	return fmt.Errorf("binding to interface %s is not supported on %s: %w", iface, runtime.GOOS, errors.ErrUnsupported)
+}
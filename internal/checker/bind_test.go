@@ -0,0 +1,150 @@
+package checker
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/containeroo/never/internal/testutils"
+)
+
+// sourceIP is a loopback address other than the default one, so tests can tell it was used.
+var sourceIP = net.ParseIP("127.0.0.2")
+
+// skipUnlessLinux skips tests of features only implemented on Linux.
+func skipUnlessLinux(t *testing.T) {
+	t.Helper()
+
+	if runtime.GOOS != "linux" {
+		t.Skip("skipping: only supported on Linux")
+	}
+}
+
+// TestTCPCheckerSourceBinding verifies TCP connections are opened from the source address and interface.
+func TestTCPCheckerSourceBinding(t *testing.T) {
+	t.Parallel()
+	skipUnlessLinux(t)
+
+	listener := testutils.ListenLocalTCP(t)
+	t.Cleanup(func() { _ = listener.Close() })
+	remote := make(chan net.Addr, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		remote <- conn.RemoteAddr()
+		_ = conn.Close()
+	}()
+
+	checker, err := newTCPChecker("bind", listener.Addr().String(), WithSourceAddress(sourceIP), WithInterface("lo"))
+	require.NoError(t, err)
+	require.NoError(t, checker.Check(context.Background()))
+
+	select {
+	case addr := <-remote:
+		assert.Equal(t, sourceIP.String(), addr.(*net.TCPAddr).IP.String())
+	case <-time.After(time.Second):
+		t.Fatal("connection was not accepted")
+	}
+}
+
+// TestHTTPCheckerSourceBinding verifies HTTP requests are sent from the source address and interface.
+func TestHTTPCheckerSourceBinding(t *testing.T) {
+	t.Parallel()
+	skipUnlessLinux(t)
+
+	var remoteAddr string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remoteAddr = r.RemoteAddr
+	}))
+	t.Cleanup(server.Close)
+
+	checker, err := newHTTPChecker("bind", server.URL, WithSourceAddress(sourceIP), WithInterface("lo"))
+	require.NoError(t, err)
+	require.NoError(t, checker.Check(context.Background()))
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	require.NoError(t, err)
+	assert.Equal(t, sourceIP.String(), host)
+}
+
+// TestDNSResolverSourceBinding verifies DNS queries are sent from the source address and interface.
+func TestDNSResolverSourceBinding(t *testing.T) {
+	t.Parallel()
+	skipUnlessLinux(t)
+
+	conn, err := net.ListenPacket("udp4", testutils.LocalhostIPv4+":0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	remote := make(chan net.Addr, 1)
+	go func() {
+		_, addr, err := conn.ReadFrom(make([]byte, 512))
+		if err == nil {
+			remote <- addr
+		}
+	}()
+
+	checker, err := newTCPChecker("bind", "db.never.test:5432",
+		WithDNSResolver(conn.LocalAddr().String(), 100*time.Millisecond), WithSourceAddress(sourceIP), WithInterface("lo"))
+	require.NoError(t, err)
+	require.Error(t, checker.Check(context.Background()))
+
+	select {
+	case addr := <-remote:
+		assert.Equal(t, sourceIP.String(), addr.(*net.UDPAddr).IP.String())
+	case <-time.After(time.Second):
+		t.Fatal("no DNS query was received")
+	}
+}
+
+// TestTCPCheckerUnknownInterface verifies binding to a missing interface fails the attempt.
+func TestTCPCheckerUnknownInterface(t *testing.T) {
+	t.Parallel()
+	skipUnlessLinux(t)
+
+	listener := testutils.ListenLocalTCP(t)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	checker, err := newTCPChecker("bind", listener.Addr().String(), WithInterface("never0"))
+	require.NoError(t, err)
+
+	err = checker.Check(context.Background())
+	require.ErrorIs(t, err, syscall.ENODEV)
+	assert.ErrorContains(t, err, "failed to bind to interface never0")
+}
+
+// TestICMPCheckerSourceBinding verifies ICMP sockets listen on the source address and are not shared across bindings.
+func TestICMPCheckerSourceBinding(t *testing.T) {
+	t.Parallel()
+
+	addresses := make(chan string, 1)
+	conn := echoConn(nil)
+	protocol := echoProtocol(conn)
+	protocol.ListenPacketFunc = func(ctx context.Context, network, address string) (net.PacketConn, error) {
+		addresses <- address
+		return conn, nil
+	}
+
+	checker := &ICMPChecker{
+		name:        "Bind",
+		address:     testutils.LocalhostIPv4,
+		protocolFor: fixedProtocol(protocol),
+		readTimeout: time.Second,
+		source:      sourceBinding{address: sourceIP, iface: "net1"},
+		listeners:   newICMPListeners(),
+	}
+	require.NoError(t, checker.Check(context.Background()))
+	assert.Equal(t, sourceIP.String(), <-addresses)
+
+	assert.Equal(t, "ip4:icmp ttl=0 df=false src=127.0.0.2 dev=net1", icmpOptions{source: checker.source}.socketKey(icmpv4Network))
+	assert.NotEqual(t, icmpOptions{}.socketKey(icmpv4Network), icmpOptions{source: checker.source}.socketKey(icmpv4Network))
+}
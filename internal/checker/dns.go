@@ -0,0 +1,102 @@
+package checker
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// defaultDNSPort is the port used for DNS servers configured without one.
+const defaultDNSPort = "53"
+
+// dnsSettings configures the resolver of a checker.
+type dnsSettings struct {
+	enabled bool // enabled is false for the system resolver.
+	server  string
+	timeout time.Duration
+}
+
+// resolver returns the resolver for the settings with queries sent from source, or nil for the system resolver.
+func (s dnsSettings) resolver(source sourceBinding) *net.Resolver {
+	if !s.enabled {
+		return nil
+	}
+
+	return newDNSResolver(s.server, s.timeout, source)
+}
+
+// newDNSResolver returns a resolver that sends queries to server instead of the system name servers.
+// An empty server keeps the name servers from /etc/resolv.conf. A timeout greater than zero limits each query.
+// Queries are sent from the source address and interface of the checker.
+func newDNSResolver(server string, timeout time.Duration, source sourceBinding) *net.Resolver {
+	if server != "" {
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			server = net.JoinHostPort(server, defaultDNSPort)
+		}
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			if server != "" {
+				address = server
+			}
+			dialer := &net.Dialer{Timeout: timeout}
+			source.apply(dialer, network)
+			conn, err := dialer.DialContext(ctx, network, address)
+			if err != nil || timeout <= 0 {
+				return conn, err
+			}
+			capped := &dnsConn{Conn: conn, deadline: time.Now().Add(timeout)}
+			if packetConn, ok := conn.(net.PacketConn); ok {
+				return &dnsPacketConn{dnsConn: capped, packetConn: packetConn}, nil
+			}
+			return capped, nil
+		},
+	}
+}
+
+// dnsConn caps the deadline the resolver sets for a query at the configured timeout.
+type dnsConn struct {
+	net.Conn
+	deadline time.Time
+}
+
+// SetDeadline sets the deadline, but not later than the DNS timeout after the connection was opened.
+func (c *dnsConn) SetDeadline(t time.Time) error {
+	if t.IsZero() || t.After(c.deadline) {
+		t = c.deadline
+	}
+
+	return c.Conn.SetDeadline(t)
+}
+
+// dnsPacketConn keeps the packet methods of UDP connections, which the resolver uses to tell UDP from TCP.
+type dnsPacketConn struct {
+	*dnsConn
+	packetConn net.PacketConn
+}
+
+// ReadFrom reads a packet from the connection.
+func (c *dnsPacketConn) ReadFrom(b []byte) (int, net.Addr, error) { return c.packetConn.ReadFrom(b) }
+
+// WriteTo writes a packet to addr.
+func (c *dnsPacketConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	return c.packetConn.WriteTo(b, addr)
+}
+
+// WithDNSResolver sets the DNS server, as ip or ip:port, and the timeout of each DNS query.
+// An empty server keeps the system name servers and a zero timeout keeps the resolver timeout.
+func WithDNSResolver(server string, timeout time.Duration) Option {
+	return OptionFunc(func(c Checker) {
+		settings := dnsSettings{enabled: true, server: server, timeout: timeout}
+		switch chk := c.(type) {
+		case *HTTPChecker:
+			chk.dns = settings
+		case *TCPChecker:
+			chk.dns = settings
+		case *ICMPChecker:
+			chk.dns = settings
+		}
+	})
+}
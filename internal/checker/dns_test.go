@@ -0,0 +1,147 @@
+package checker
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/containeroo/never/internal/testutils"
+)
+
+// serveDNS starts a UDP DNS server that answers A queries with the records in hosts.
+// Other names get NXDOMAIN. With silent set, no query is answered.
+func serveDNS(t *testing.T, hosts map[string]net.IP, silent bool) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp4", testutils.LocalhostAddr("0"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if silent {
+				continue
+			}
+
+			var query dnsmessage.Message
+			if err := query.Unpack(buf[:n]); err != nil || len(query.Questions) != 1 {
+				continue
+			}
+			question := query.Questions[0]
+
+			reply := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: query.ID, Response: true, Authoritative: true, RCode: dnsmessage.RCodeNameError},
+				Questions: query.Questions,
+			}
+			if ip, ok := hosts[question.Name.String()]; ok {
+				reply.RCode = dnsmessage.RCodeSuccess
+				if question.Type == dnsmessage.TypeA {
+					var a dnsmessage.AResource
+					copy(a.A[:], ip.To4())
+					reply.Answers = []dnsmessage.Resource{{
+						Header: dnsmessage.ResourceHeader{Name: question.Name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET, TTL: 60},
+						Body:   &a,
+					}}
+				}
+			}
+
+			packed, err := reply.Pack()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(packed, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+// TestDNSResolverServer verifies every checker type resolves hostnames through the configured DNS server.
+func TestDNSResolverServer(t *testing.T) {
+	t.Parallel()
+
+	dnsServer := serveDNS(t, map[string]net.IP{"db.never.test.": net.ParseIP(testutils.LocalhostIPv4)}, false)
+
+	listener := testutils.ListenLocalTCP(t)
+	t.Cleanup(func() { _ = listener.Close() })
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	address := net.JoinHostPort("db.never.test", port)
+
+	t.Run("TCP", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := newTCPChecker("dns", address, WithDNSResolver(dnsServer, time.Second))
+		require.NoError(t, err)
+		require.NoError(t, checker.Check(context.Background()))
+	})
+
+	t.Run("HTTP", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		t.Cleanup(server.Close)
+		_, httpPort, err := net.SplitHostPort(server.Listener.Addr().String())
+		require.NoError(t, err)
+
+		checker, err := newHTTPChecker("dns", "http://"+net.JoinHostPort("db.never.test", httpPort), WithDNSResolver(dnsServer, time.Second))
+		require.NoError(t, err)
+		require.NoError(t, checker.Check(context.Background()))
+	})
+
+	t.Run("TCP resolve all", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := newTCPChecker("dns", address, WithDNSResolver(dnsServer, time.Second), WithResolveMode(ResolveAll, 1))
+		require.NoError(t, err)
+		require.NoError(t, checker.Check(context.Background()))
+	})
+
+	t.Run("ICMP", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := newICMPChecker("dns", "db.never.test", WithDNSResolver(dnsServer, time.Second), WithICMPIPFamily(IPFamily4))
+		require.NoError(t, err)
+		ip, err := checker.resolve(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, testutils.LocalhostIPv4, ip.String())
+	})
+
+	t.Run("unknown name", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := newTCPChecker("dns", net.JoinHostPort("web.never.test", port), WithDNSResolver(dnsServer, time.Second))
+		require.NoError(t, err)
+
+		var dnsErr *net.DNSError
+		require.ErrorAs(t, checker.Check(context.Background()), &dnsErr)
+		assert.True(t, dnsErr.IsNotFound)
+	})
+}
+
+// TestDNSResolverTimeout verifies the DNS timeout limits queries to a server that does not answer.
+func TestDNSResolverTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := serveDNS(t, nil, true)
+
+	start := time.Now()
+	_, err := newDNSResolver(server, 50*time.Millisecond, sourceBinding{}).LookupIP(context.Background(), "ip4", "db.never.test")
+
+	var dnsErr *net.DNSError
+	require.ErrorAs(t, err, &dnsErr)
+	assert.True(t, dnsErr.IsTimeout)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
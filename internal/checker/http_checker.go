@@ -4,19 +4,61 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
+	"net/url"
 	"slices"
+	"sync/atomic"
 	"time"
 )
 
 const (
-	defaultHTTPTimeout       time.Duration = 2 * time.Second
-	defaultHTTPMethod        string        = http.MethodGet
-	defaultHTTPSkipTLSVerify bool          = false
+	defaultHTTPTimeout         time.Duration = 2 * time.Second
+	defaultHTTPMethod          string        = http.MethodGet
+	defaultHTTPSkipTLSVerify   bool          = false
+	defaultHTTPFollowRedirects bool          = true
+	defaultHTTPMaxRedirects    int           = 10
 )
 
 var defaultHTTPExpectedStatusCodes = []int{200}
 
+// UnexpectedStatusCodeError is returned when a response status code is not one of the expected codes.
+type UnexpectedStatusCodeError struct {
+	StatusCode int
+	Expected   []int
+}
+
+// Error returns the error message.
+func (e *UnexpectedStatusCodeError) Error() string {
+	return fmt.Sprintf("unexpected status code: got %d, expected one of %v", e.StatusCode, e.Expected)
+}
+
+// UnexpectedFinalURLError is returned when the URL a request was redirected to is not the expected one.
+type UnexpectedFinalURLError struct {
+	FinalURL string
+	Expected string
+}
+
+// Error returns the error message.
+func (e *UnexpectedFinalURLError) Error() string {
+	return fmt.Sprintf("unexpected final URL: got %s, expected %s", e.FinalURL, e.Expected)
+}
+
+// HTTPRedirects describes the redirects followed by a request.
+type HTTPRedirects struct {
+	Count    int
+	FinalURL string
+}
+
+// LogValue implements slog.LogValuer.
+func (r HTTPRedirects) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("redirects", r.Count),
+		slog.String("final_url", r.FinalURL),
+	)
+}
+
 // HTTPChecker implements the Checker interface for HTTP checks.
 type HTTPChecker struct {
 	name                string
@@ -24,9 +66,34 @@ type HTTPChecker struct {
 	method              string
 	headers             http.Header
 	expectedStatusCodes []int
+	failFastStatusCodes []int
+	failFastTLS         bool
 	skipTLSVerify       bool
+	followRedirects     bool
+	maxRedirects        int
+	expectedFinalURL    string
 	timeout             time.Duration
+	resolveOverrides    resolveOverrides
+	dns                 dnsSettings
+	proxy               *url.URL // proxy replaces the proxy from the environment when set.
+	noProxy             bool
+	source              sourceBinding
+	oauth2              *OAuth2Config
+	tokenSource         *oauth2TokenSource // tokenSource fetches the bearer token when OAuth2 is configured.
 	client              *http.Client
+	lastRedirects       atomic.Pointer[HTTPRedirects]
+
+	multiAddress multiAddress
+}
+
+// pinnedIPKey is the context key for the pinnedIP an HTTP request must connect to.
+type pinnedIPKey struct{}
+
+// pinnedIP is the resolved address a request to host must connect to.
+// Redirects to other hosts are not pinned.
+type pinnedIP struct {
+	host string
+	ip   net.IP
 }
 
 // Address returns the checker address.
@@ -40,9 +107,12 @@ func (c *HTTPChecker) Type() string { return HTTP.String() }
 
 // Check performs the checker operation.
 func (c *HTTPChecker) Check(ctx context.Context) error {
+	// Forget the redirects of the previous attempt so they are not logged again.
+	c.lastRedirects.Store(nil)
+
 	req, err := http.NewRequestWithContext(ctx, c.method, c.address, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return &PermanentError{Err: fmt.Errorf("failed to create request: %w", err)}
 	}
 
 	for key, values := range c.headers {
@@ -51,21 +121,99 @@ func (c *HTTPChecker) Check(ctx context.Context) error {
 		}
 	}
 
+	if c.tokenSource != nil {
+		token, err := c.tokenSource.Token(ctx)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if c.multiAddress.enabled() {
+		return c.multiAddress.check(ctx, req.URL.Hostname(), func(ctx context.Context, ip net.IP) error {
+			return c.do(req.WithContext(context.WithValue(ctx, pinnedIPKey{}, pinnedIP{host: req.URL.Hostname(), ip: ip})))
+		})
+	}
+
+	return c.do(req)
+}
+
+// do sends req and checks the response.
+func (c *HTTPChecker) do(req *http.Request) error {
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("HTTP request failed: %w", err)
+		err = fmt.Errorf("HTTP request failed: %w", err)
+		if c.failFastTLS && isCertificateError(err) {
+			return &PermanentError{Err: err}
+		}
+		return err
 	}
 	defer resp.Body.Close() // nolint:errcheck
 
+	if resp.StatusCode == http.StatusUnauthorized && c.tokenSource != nil {
+		// The token may have been revoked, so the next attempt fetches a new one.
+		c.tokenSource.Invalidate()
+	}
+
+	redirects := followedRedirects(resp)
+	if redirects.Count > 0 {
+		c.lastRedirects.Store(&redirects)
+	}
+
 	if slices.Contains(c.expectedStatusCodes, resp.StatusCode) {
+		if c.expectedFinalURL != "" && redirects.FinalURL != c.expectedFinalURL {
+			return &UnexpectedFinalURLError{FinalURL: redirects.FinalURL, Expected: c.expectedFinalURL}
+		}
 		return nil
 	}
 
-	return fmt.Errorf("unexpected status code: got %d, expected one of %v", resp.StatusCode, c.expectedStatusCodes)
+	err = &UnexpectedStatusCodeError{StatusCode: resp.StatusCode, Expected: c.expectedStatusCodes}
+	if slices.Contains(c.failFastStatusCodes, resp.StatusCode) {
+		return &PermanentError{Err: err}
+	}
+	if honoursRetryAfter(resp.StatusCode) {
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return &RetryAfterError{Delay: delay, Err: err}
+		}
+	}
+
+	return err
+}
+
+// LastStats returns the redirects followed by the most recent attempt, if any.
+func (c *HTTPChecker) LastStats() (slog.Value, bool) {
+	redirects := c.lastRedirects.Load()
+	if redirects == nil {
+		return slog.Value{}, false
+	}
+
+	return redirects.LogValue(), true
+}
+
+// followedRedirects returns the number of redirects that led to resp and the URL it was served from.
+func followedRedirects(resp *http.Response) HTTPRedirects {
+	redirects := HTTPRedirects{FinalURL: resp.Request.URL.String()}
+	for req := resp.Request; req.Response != nil; req = req.Response.Request {
+		redirects.Count++
+	}
+
+	return redirects
+}
+
+// checkRedirect applies the redirect policy. Without following, the redirect response is checked itself.
+func (c *HTTPChecker) checkRedirect(_ *http.Request, via []*http.Request) error {
+	if !c.followRedirects {
+		return http.ErrUseLastResponse
+	}
+	if len(via) > c.maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", c.maxRedirects)
+	}
+
+	return nil
 }
 
 // newHTTPChecker creates a new HTTPChecker with functional options.
-func newHTTPChecker(name, address string, opts ...Option) (*HTTPChecker, error) { // nolint:unparam
+func newHTTPChecker(name, address string, opts ...Option) (*HTTPChecker, error) {
 	checker := &HTTPChecker{
 		name:                name,
 		address:             address,
@@ -73,6 +221,8 @@ func newHTTPChecker(name, address string, opts ...Option) (*HTTPChecker, error)
 		headers:             make(http.Header),
 		expectedStatusCodes: defaultHTTPExpectedStatusCodes,
 		skipTLSVerify:       defaultHTTPSkipTLSVerify,
+		followRedirects:     defaultHTTPFollowRedirects,
+		maxRedirects:        defaultHTTPMaxRedirects,
 		timeout:             defaultHTTPTimeout,
 	}
 
@@ -80,19 +230,71 @@ func newHTTPChecker(name, address string, opts ...Option) (*HTTPChecker, error)
 		opt.apply(checker)
 	}
 
-	checker.client = &http.Client{
-		Timeout: checker.timeout,
-		Transport: &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: checker.skipTLSVerify,
-			},
+	resolver := checker.dns.resolver(checker.source)
+	checker.multiAddress.resolver = resolver
+	dialer := &net.Dialer{Resolver: resolver}
+	checker.source.apply(dialer, "tcp")
+
+	transport := &http.Transport{
+		Proxy:       proxyFromEnvironment(),
+		DialContext: dialPinnedIP(dialer, checker.resolveOverrides),
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: checker.skipTLSVerify,
 		},
 	}
+	if checker.multiAddress.enabled() {
+		// Every address must be reached directly and on its own connection.
+		transport.Proxy = nil
+		transport.DisableKeepAlives = true
+	}
+	if checker.dns.server != "" || checker.noProxy {
+		// A proxy would resolve the hostname itself. A DNS timeout alone keeps the proxy.
+		transport.Proxy = nil
+	}
+	if len(checker.resolveOverrides) > 0 && transport.Proxy != nil {
+		transport.Proxy = checker.resolveOverrides.bypassProxy(transport.Proxy)
+	}
+	if checker.proxy != nil {
+		if err := checkProxyScheme(checker.proxy, HTTPProxySchemes); err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(checker.proxy)
+	}
+
+	checker.client = &http.Client{
+		Timeout:       checker.timeout,
+		Transport:     transport,
+		CheckRedirect: checker.checkRedirect,
+	}
+
+	if checker.oauth2 != nil {
+		// The token endpoint is reached like the target, but its certificate is always verified.
+		tokenTransport := transport.Clone()
+		tokenTransport.TLSClientConfig = nil
+		checker.tokenSource = newOAuth2TokenSource(*checker.oauth2, &http.Client{Timeout: checker.timeout, Transport: tokenTransport})
+	}
 
 	return checker, nil
 }
 
+// dialPinnedIP returns a dial function that connects to the IP pinned in the context or to a resolve override, if any.
+// The request keeps its hostname for the Host header and TLS server name.
+func dialPinnedIP(dialer *net.Dialer, overrides resolveOverrides) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		address = overrides.address(address)
+		if pinned, ok := ctx.Value(pinnedIPKey{}).(pinnedIP); ok {
+			host, port, err := net.SplitHostPort(address)
+			if err != nil {
+				return nil, err
+			}
+			if host == pinned.host {
+				address = net.JoinHostPort(pinned.ip.String(), port)
+			}
+		}
+		return dialer.DialContext(ctx, network, address)
+	}
+}
+
 // WithHTTPMethod sets the HTTP method for the HTTPChecker.
 func WithHTTPMethod(method string) Option {
 	return OptionFunc(func(c Checker) {
@@ -120,6 +322,24 @@ func WithExpectedStatusCodes(codes []int) Option {
 	})
 }
 
+// WithFailFastStatusCodes sets the status codes that fail the HTTPChecker permanently.
+func WithFailFastStatusCodes(codes []int) Option {
+	return OptionFunc(func(c Checker) {
+		if httpChecker, ok := c.(*HTTPChecker); ok {
+			httpChecker.failFastStatusCodes = codes
+		}
+	})
+}
+
+// WithHTTPFailFastTLS sets whether TLS certificate verification failures stop retrying the target.
+func WithHTTPFailFastTLS(failFast bool) Option {
+	return OptionFunc(func(c Checker) {
+		if httpChecker, ok := c.(*HTTPChecker); ok {
+			httpChecker.failFastTLS = failFast
+		}
+	})
+}
+
 // WithHTTPSkipTLSVerify sets the TLS verification flag for the HTTPChecker.
 func WithHTTPSkipTLSVerify(skip bool) Option {
 	return OptionFunc(func(c Checker) {
@@ -129,6 +349,33 @@ func WithHTTPSkipTLSVerify(skip bool) Option {
 	})
 }
 
+// WithHTTPFollowRedirects sets whether the HTTPChecker follows redirects.
+func WithHTTPFollowRedirects(follow bool) Option {
+	return OptionFunc(func(c Checker) {
+		if httpChecker, ok := c.(*HTTPChecker); ok {
+			httpChecker.followRedirects = follow
+		}
+	})
+}
+
+// WithHTTPMaxRedirects sets the number of redirects the HTTPChecker follows before failing.
+func WithHTTPMaxRedirects(maxRedirects int) Option {
+	return OptionFunc(func(c Checker) {
+		if httpChecker, ok := c.(*HTTPChecker); ok {
+			httpChecker.maxRedirects = maxRedirects
+		}
+	})
+}
+
+// WithHTTPExpectedFinalURL sets the URL a request must end at after following redirects.
+func WithHTTPExpectedFinalURL(url string) Option {
+	return OptionFunc(func(c Checker) {
+		if httpChecker, ok := c.(*HTTPChecker); ok {
+			httpChecker.expectedFinalURL = url
+		}
+	})
+}
+
 // WithHTTPTimeout sets the timeout for the HTTPChecker.
 func WithHTTPTimeout(timeout time.Duration) Option {
 	return OptionFunc(func(c Checker) {
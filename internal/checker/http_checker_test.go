@@ -3,6 +3,7 @@ package checker
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -79,6 +80,10 @@ func TestHTTPChecker(t *testing.T) {
 		err = checker.Check(ctx)
 		require.Error(t, err)
 		assert.EqualError(t, err, "unexpected status code: got 404, expected one of [200]")
+
+		var statusErr *UnexpectedStatusCodeError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
 	})
 
 	t.Run("Invalid URL for HTTP check", func(t *testing.T) {
@@ -177,3 +182,93 @@ func TestHTTPChecker(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+// TestHTTPCheckerRedirects verifies redirects are followed or checked according to the redirect policy.
+func TestHTTPCheckerRedirects(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/login", http.StatusFound)
+	})
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Run("followed by default", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := newHTTPChecker("example", server.URL)
+		require.NoError(t, err)
+
+		require.NoError(t, checker.Check(context.Background()))
+		assert.Equal(t, &HTTPRedirects{Count: 1, FinalURL: server.URL + "/login"}, checker.lastRedirects.Load())
+
+		stats, ok := LastStats(checker)
+		require.True(t, ok)
+		assert.Equal(t, slog.KindGroup, stats.Kind())
+	})
+
+	t.Run("not followed", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := newHTTPChecker("example", server.URL, WithHTTPFollowRedirects(false))
+		require.NoError(t, err)
+
+		var statusErr *UnexpectedStatusCodeError
+		require.ErrorAs(t, checker.Check(context.Background()), &statusErr)
+		assert.Equal(t, http.StatusFound, statusErr.StatusCode)
+
+		checker, err = newHTTPChecker("example", server.URL, WithHTTPFollowRedirects(false), WithExpectedStatusCodes([]int{302}))
+		require.NoError(t, err)
+		require.NoError(t, checker.Check(context.Background()))
+		assert.Nil(t, checker.lastRedirects.Load())
+	})
+
+	t.Run("reset without redirects", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := newHTTPChecker("example", server.URL)
+		require.NoError(t, err)
+
+		require.NoError(t, checker.Check(context.Background()))
+		require.NotNil(t, checker.lastRedirects.Load())
+
+		checker.address = server.URL + "/login"
+		require.NoError(t, checker.Check(context.Background()))
+		_, ok := LastStats(checker)
+		assert.False(t, ok)
+	})
+
+	t.Run("too many redirects", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := newHTTPChecker("example", server.URL+"/loop", WithHTTPMaxRedirects(3))
+		require.NoError(t, err)
+
+		err = checker.Check(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "stopped after 3 redirects")
+	})
+
+	t.Run("unexpected final URL", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := newHTTPChecker("example", server.URL, WithHTTPExpectedFinalURL(server.URL+"/"))
+		require.NoError(t, err)
+
+		err = checker.Check(context.Background())
+		var urlErr *UnexpectedFinalURLError
+		require.ErrorAs(t, err, &urlErr)
+		assert.EqualError(t, err, fmt.Sprintf("unexpected final URL: got %s/login, expected %s/", server.URL, server.URL))
+
+		checker, err = newHTTPChecker("example", server.URL, WithHTTPExpectedFinalURL(server.URL+"/login"))
+		require.NoError(t, err)
+		require.NoError(t, checker.Check(context.Background()))
+	})
+}
@@ -2,11 +2,16 @@ package checker
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net"
 	"os"
 	"sync/atomic"
+	"syscall"
 	"time"
+
+	"github.com/containeroo/never/internal/utils"
 )
 
 const (
@@ -22,7 +27,23 @@ type ICMPChecker struct {
 	address      string
 	readTimeout  time.Duration
 	writeTimeout time.Duration
-	protocol     Protocol
+	ipFamily     IPFamily
+	privileged   ICMPPrivileged
+	datagram     atomic.Bool                                // datagram is set once auto mode fell back to ping sockets.
+	protocolFor  func(ip net.IP, opts icmpOptions) Protocol // protocolFor returns the protocol used to ping ip.
+	size         int
+	ttl          int
+	dontFragment bool
+	source       sourceBinding
+	count        int
+	maxLoss      float64 // maxLoss is the highest tolerated packet loss in percent.
+	maxRTT       time.Duration
+	lastStats    atomic.Pointer[ICMPStats]
+	listeners    *icmpListeners // listeners defaults to the listeners shared by the process.
+	resolver     *net.Resolver  // resolver defaults to the system resolver when nil.
+	dns          dnsSettings
+
+	multiAddress multiAddress
 }
 
 // Address returns the checker address.
@@ -36,45 +57,202 @@ func (c *ICMPChecker) Type() string { return ICMP.String() }
 
 // Check performs the checker operation.
 func (c *ICMPChecker) Check(ctx context.Context) error {
-	dst, err := net.ResolveIPAddr(c.protocol.Network(), c.address)
+	if c.multiAddress.enabled() {
+		return c.multiAddress.check(ctx, c.address, func(ctx context.Context, ip net.IP) error {
+			_, err := c.pingIP(ctx, ip)
+			return err
+		})
+	}
+
+	// Resolve on every attempt so a hostname that does not exist yet is waited for.
+	ip, err := c.resolve(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to resolve IP address '%s': %w", c.address, err)
 	}
 
-	conn, err := c.protocol.ListenPacket(ctx, c.protocol.Network(), "")
+	stats, err := c.pingIP(ctx, ip)
+	if stats.Sent > 0 {
+		c.lastStats.Store(&stats)
+	}
+
+	return err
+}
+
+// LastStats returns the statistics of the most recent single-address attempt.
+func (c *ICMPChecker) LastStats() (slog.Value, bool) {
+	stats := c.lastStats.Load()
+	if stats == nil {
+		return slog.Value{}, false
+	}
+
+	return stats.LogValue(), true
+}
+
+// pingIP pings ip over a raw or a ping socket, depending on the privileged mode.
+func (c *ICMPChecker) pingIP(ctx context.Context, ip net.IP) (ICMPStats, error) {
+	switch c.privileged {
+	case ICMPPrivilegedTrue:
+		stats, err := c.ping(ctx, ip, false)
+		return stats, c.permissionError(err)
+	case ICMPPrivilegedFalse:
+		stats, err := c.ping(ctx, ip, true)
+		return stats, c.permissionError(err)
+	}
+
+	if !c.datagram.Load() {
+		stats, err := c.ping(ctx, ip, false)
+		if !isPermissionDenied(err) {
+			return stats, err
+		}
+		c.datagram.Store(true)
+	}
+
+	stats, err := c.ping(ctx, ip, true)
+	return stats, c.permissionError(err)
+}
+
+// permissionError marks a missing socket permission as permanent and names the permission.
+func (c *ICMPChecker) permissionError(err error) error {
+	if !isPermissionDenied(err) {
+		return err
+	}
+
+	return &PermanentError{Err: &ICMPPermissionError{Privileged: c.privileged, Err: err}}
+}
+
+// resolve returns the address to ping, restricted to the configured IP family.
+func (c *ICMPChecker) resolve(ctx context.Context) (net.IP, error) {
+	if ip := net.ParseIP(c.address); ip != nil {
+		return ip, nil
+	}
+
+	ips, err := c.resolver.LookupIP(ctx, c.ipFamily.network(), c.address)
 	if err != nil {
-		return fmt.Errorf("failed to listen for ICMP packets: %w", err)
+		return nil, err
 	}
-	defer conn.Close() // nolint:errcheck
+	if len(ips) == 0 {
+		return nil, errors.New("no addresses resolved")
+	}
+
+	return ips[0], nil
+}
 
-	id := uint16(os.Getpid() & 0xffff)                    // Process-scoped identifier
-	seq := uint16(atomic.AddUint32(&icmpSeq, 1) & 0xffff) // Monotonic sequence number
+// ping sends a burst of echo requests to ip and compares the replies with the loss and RTT thresholds.
+// Replies are read by the listener shared by all checks with the same network and socket options.
+func (c *ICMPChecker) ping(ctx context.Context, ip net.IP, datagram bool) (ICMPStats, error) {
+	opts := icmpOptions{datagram: datagram, size: c.size, ttl: c.ttl, dontFragment: c.dontFragment, source: c.source}
+	protocol := c.protocolFor(ip, opts)
 
-	msg, err := c.protocol.MakeRequest(id, seq)
+	listeners := c.listeners
+	if listeners == nil {
+		listeners = defaultICMPListeners
+	}
+
+	listener, err := listeners.acquire(ctx, opts.socketKey(protocol.Network()), protocol, c.source.listenAddress())
 	if err != nil {
-		return fmt.Errorf("failed to create ICMP request: %w", err)
+		return ICMPStats{}, fmt.Errorf("failed to listen for ICMP packets: %w", err)
 	}
+	defer listeners.release(listener)
 
-	if err := conn.SetWriteDeadline(time.Now().Add(c.writeTimeout)); err != nil {
-		return fmt.Errorf("failed to set write deadline: %w", err)
+	// Ping sockets are datagram sockets and address the target like a UDP peer.
+	var addr net.Addr = &net.IPAddr{IP: ip}
+	if datagram {
+		addr = &net.UDPAddr{IP: ip}
 	}
 
-	if _, err := conn.WriteTo(msg, dst); err != nil {
-		return fmt.Errorf("failed to send ICMP request: %w", err)
+	count := max(c.count, 1)
+	sent := make([]uint16, 0, count)
+	sentAt := make(map[uint16]time.Time, count)
+	replies := make(chan icmpReply, count)
+	defer func() {
+		for _, seq := range sent {
+			listener.unregister(seq)
+		}
+	}()
+
+	for range count {
+		seq := uint16(atomic.AddUint32(&icmpSeq, 1) & 0xffff) // Monotonic sequence number
+
+		msg, err := protocol.MakeRequest(icmpID, seq)
+		if err != nil {
+			return ICMPStats{}, fmt.Errorf("failed to create ICMP request: %w", err)
+		}
+
+		if err := listener.conn.SetWriteDeadline(time.Now().Add(c.writeTimeout)); err != nil {
+			return ICMPStats{}, fmt.Errorf("failed to set write deadline: %w", err)
+		}
+
+		// Register before sending so a fast reply is not dropped.
+		listener.register(seq, replies)
+		sent = append(sent, seq)
+		sentAt[seq] = time.Now()
+		if _, err := listener.conn.WriteTo(msg, addr); err != nil {
+			if errors.Is(err, syscall.EMSGSIZE) {
+				// Requests above the known path MTU are refused locally when fragmentation is not allowed.
+				return ICMPStats{}, fmt.Errorf("failed to send ICMP request of %d bytes: %w: %w", len(msg), ErrFragmentationNeeded, err)
+			}
+			return ICMPStats{}, fmt.Errorf("failed to send ICMP request: %w", err)
+		}
 	}
 
-	if err := conn.SetReadDeadline(time.Now().Add(c.readTimeout)); err != nil {
-		return fmt.Errorf("failed to set read deadline: %w", err)
+	timer := time.NewTimer(c.readTimeout)
+	defer timer.Stop()
+
+	rtts := make(map[uint16]time.Duration, count)
+	var timeoutErr error
+	for len(rtts) < count && timeoutErr == nil {
+		select {
+		case r := <-replies:
+			if r.err != nil {
+				return ICMPStats{}, fmt.Errorf("failed to read ICMP reply: %w", r.err)
+			}
+			if _, ok := rtts[r.seq]; ok {
+				continue // Duplicate reply.
+			}
+			if err := protocol.ValidateReply(r.data, icmpID, r.seq); err != nil {
+				var msgErr *ICMPMessageError
+				if !errors.As(err, &msgErr) {
+					continue // Not an answer to this check.
+				}
+				msgErr.From = addrIP(r.from)
+				return newICMPStats(sent, rtts), msgErr
+			}
+			rtts[r.seq] = r.received.Sub(sentAt[r.seq])
+		case <-timer.C:
+			timeoutErr = os.ErrDeadlineExceeded // Unanswered requests count as lost.
+		case <-ctx.Done():
+			return ICMPStats{}, fmt.Errorf("failed to read ICMP reply: %w", ctx.Err())
+		}
 	}
 
-	reply := make([]byte, 1500)
-	n, _, err := conn.ReadFrom(reply)
-	if err != nil {
-		return fmt.Errorf("failed to read ICMP reply: %w", err)
+	stats := newICMPStats(sent, rtts)
+	return stats, c.evaluate(stats, timeoutErr)
+}
+
+// addrIP returns the IP address of a raw or ping socket peer.
+func addrIP(addr net.Addr) net.IP {
+	switch a := addr.(type) {
+	case *net.IPAddr:
+		return a.IP
+	case *net.UDPAddr:
+		return a.IP
+	default:
+		return nil
 	}
+}
 
-	if err := c.protocol.ValidateReply(reply[:n], id, seq); err != nil {
-		return fmt.Errorf("failed to validate ICMP reply: %w", err)
+// evaluate compares the statistics of a burst with the loss and RTT thresholds.
+func (c *ICMPChecker) evaluate(stats ICMPStats, timeoutErr error) error {
+	if stats.Received == 0 {
+		return &ICMPStatsError{Reason: "no ICMP reply received", Stats: stats, Err: timeoutErr}
+	}
+	if stats.Loss() > c.maxLoss {
+		reason := fmt.Sprintf("packet loss %g%% exceeds max loss %g%%", stats.Loss(), c.maxLoss)
+		return &ICMPStatsError{Reason: reason, Stats: stats, Err: timeoutErr}
+	}
+	if c.maxRTT > 0 && stats.AvgRTT > c.maxRTT {
+		reason := fmt.Sprintf("average rtt %s exceeds max rtt %s", roundRTT(stats.AvgRTT), c.maxRTT)
+		return &ICMPStatsError{Reason: reason, Stats: stats}
 	}
 
 	return nil
@@ -87,21 +265,43 @@ func newICMPChecker(name, address string, opts ...Option) (*ICMPChecker, error)
 		address:      address,
 		readTimeout:  defaultICMPReadTimeout,
 		writeTimeout: defaultICMPWriteTimeout,
+		ipFamily:     IPFamilyAny,
+		privileged:   ICMPPrivilegedAuto,
+		protocolFor:  protocolForIP,
 	}
 
 	for _, opt := range opts {
 		opt.apply(checker)
 	}
 
-	protocol, err := newProtocol(checker.address)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create ICMP protocol: %w", err)
+	if err := checker.validateAddress(); err != nil {
+		return nil, err
 	}
-	checker.protocol = protocol
+	checker.multiAddress.network = checker.ipFamily.network()
+	checker.resolver = checker.dns.resolver(checker.source)
+	checker.multiAddress.resolver = checker.resolver
 
 	return checker, nil
 }
 
+// validateAddress checks that the address is a hostname or an IP address of the configured family.
+// Hostnames are resolved on every attempt, not here.
+func (c *ICMPChecker) validateAddress() error {
+	ip := net.ParseIP(c.address)
+	if ip == nil {
+		if !utils.IsHostnameLike(c.address) {
+			return fmt.Errorf("invalid ICMP address %q: must be a hostname or IP address", c.address)
+		}
+		return nil
+	}
+
+	if (c.ipFamily == IPFamily4 && ip.To4() == nil) || (c.ipFamily == IPFamily6 && ip.To4() != nil) {
+		return fmt.Errorf("ICMP address %s is not an IPv%s address", c.address, c.ipFamily)
+	}
+
+	return nil
+}
+
 // WithICMPTimeout sets the read and write timeout for the ICMPChecker.
 func WithICMPTimeout(timeout time.Duration) Option {
 	return OptionFunc(func(c Checker) {
@@ -132,3 +332,66 @@ func WithICMPWriteTimeout(timeout time.Duration) Option {
 		}
 	})
 }
+
+// WithICMPCount sets the number of echo requests the ICMPChecker sends per attempt.
+func WithICMPCount(count int) Option {
+	return OptionFunc(func(c Checker) {
+		if icmpChecker, ok := c.(*ICMPChecker); ok {
+			icmpChecker.count = count
+		}
+	})
+}
+
+// WithICMPMaxLoss sets the highest packet loss in percent the ICMPChecker tolerates.
+func WithICMPMaxLoss(percent float64) Option {
+	return OptionFunc(func(c Checker) {
+		if icmpChecker, ok := c.(*ICMPChecker); ok {
+			icmpChecker.maxLoss = percent
+		}
+	})
+}
+
+// WithICMPMaxRTT sets the highest average round-trip time the ICMPChecker tolerates.
+func WithICMPMaxRTT(rtt time.Duration) Option {
+	return OptionFunc(func(c Checker) {
+		if icmpChecker, ok := c.(*ICMPChecker); ok {
+			icmpChecker.maxRTT = rtt
+		}
+	})
+}
+
+// WithICMPSize sets the echo payload size in bytes of the ICMPChecker.
+func WithICMPSize(size int) Option {
+	return OptionFunc(func(c Checker) {
+		if icmpChecker, ok := c.(*ICMPChecker); ok {
+			icmpChecker.size = size
+		}
+	})
+}
+
+// WithICMPTTL sets the IPv4 TTL or IPv6 hop limit of the echo requests of the ICMPChecker.
+func WithICMPTTL(ttl int) Option {
+	return OptionFunc(func(c Checker) {
+		if icmpChecker, ok := c.(*ICMPChecker); ok {
+			icmpChecker.ttl = ttl
+		}
+	})
+}
+
+// WithICMPDontFragment forbids fragmentation of the echo requests of the ICMPChecker.
+func WithICMPDontFragment(dontFragment bool) Option {
+	return OptionFunc(func(c Checker) {
+		if icmpChecker, ok := c.(*ICMPChecker); ok {
+			icmpChecker.dontFragment = dontFragment
+		}
+	})
+}
+
+// WithICMPIPFamily restricts the resolved addresses of the ICMPChecker to one IP family.
+func WithICMPIPFamily(family IPFamily) Option {
+	return OptionFunc(func(c Checker) {
+		if icmpChecker, ok := c.(*ICMPChecker); ok {
+			icmpChecker.ipFamily = family
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"os"
 	"testing"
 	"time"
 
@@ -12,9 +13,6 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/containeroo/never/internal/testutils"
-
-	"golang.org/x/net/icmp"
-	"golang.org/x/net/ipv4"
 )
 
 // TestNewICMPCheckerValidIPv4 tests creating an ICMPChecker with a valid IPv4 address.
@@ -34,44 +32,62 @@ func TestNewICMPCheckerValidIPv4(t *testing.T) {
 func TestNewICMPCheckerInvalidAddress(t *testing.T) {
 	t.Parallel()
 
-	_, err := newICMPChecker("InvalidAddress", "invalid-address")
+	_, err := newICMPChecker("InvalidAddress", "invalid/address")
 	require.Error(t, err)
-	assert.Equal(t, err.Error(), "failed to create ICMP protocol: invalid or unresolvable address: invalid-address")
+	assert.Equal(t, err.Error(), `invalid ICMP address "invalid/address": must be a hostname or IP address`)
+}
+
+// TestNewICMPCheckerUnresolvable tests creating an ICMPChecker for a hostname that does not resolve yet.
+func TestNewICMPCheckerUnresolvable(t *testing.T) {
+	t.Parallel()
+
+	checker, err := newICMPChecker("Unresolvable", "unresolvable.invalid")
+
+	require.NoError(t, err)
+	assert.Equal(t, "unresolvable.invalid", checker.Address())
+}
+
+// TestNewICMPCheckerIPFamilyMismatch tests creating an ICMPChecker for an IP address of the wrong family.
+func TestNewICMPCheckerIPFamilyMismatch(t *testing.T) {
+	t.Parallel()
+
+	_, err := newICMPChecker("Mismatch", testutils.LocalhostIPv4, WithICMPIPFamily(IPFamily6))
+	require.Error(t, err)
+	assert.EqualError(t, err, "ICMP address 127.0.0.1 is not an IPv6 address")
+
+	_, err = newICMPChecker("Mismatch", "::1", WithICMPIPFamily(IPFamily4))
+	require.Error(t, err)
+	assert.EqualError(t, err, "ICMP address ::1 is not an IPv4 address")
+}
+
+// TestICMPCheckerResolveIPFamily tests hostnames are resolved to the configured IP family on every attempt.
+func TestICMPCheckerResolveIPFamily(t *testing.T) {
+	t.Parallel()
+
+	checker, err := newICMPChecker("Family", "localhost", WithICMPIPFamily(IPFamily4))
+	require.NoError(t, err)
+
+	ip, err := checker.resolve(context.Background())
+	require.NoError(t, err)
+	assert.NotNil(t, ip.To4())
+	assert.IsType(t, &ICMPv4{}, checker.protocolFor(ip, icmpOptions{}))
+}
+
+// fixedProtocol returns a protocol selector that always returns p.
+func fixedProtocol(p Protocol) func(net.IP, icmpOptions) Protocol {
+	return func(net.IP, icmpOptions) Protocol { return p }
 }
 
 // TestICMPCheckerCheckSuccess tests successful ICMP checking.
 func TestICMPCheckerCheckSuccess(t *testing.T) {
 	t.Parallel()
 
-	mockProtocol := &testutils.MockProtocol{
-		MakeRequestFunc: func(id, seq uint16) ([]byte, error) {
-			msg := icmp.Message{
-				Type: ipv4.ICMPTypeEcho,
-				Code: 0,
-				Body: &icmp.Echo{
-					ID:   int(id),
-					Seq:  int(seq),
-					Data: []byte("HELLO-R-U-THERE"),
-				},
-			}
-			return msg.Marshal(nil)
-		},
-		ValidateReplyFunc: func(reply []byte, id, seq uint16) error {
-			return nil
-		},
-		NetworkFunc: func() string {
-			return icmpv4Network
-		},
-		ListenPacketFunc: func(ctx context.Context, network, address string) (net.PacketConn, error) {
-			return &testutils.MockPacketConn{}, nil
-		},
-	}
-
 	checker := &ICMPChecker{
 		name:        "SuccessChecker",
 		address:     testutils.LocalhostIPv4,
-		protocol:    mockProtocol,
+		protocolFor: fixedProtocol(echoProtocol(echoConn(nil))),
 		readTimeout: 2 * time.Second,
+		listeners:   newICMPListeners(),
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -94,8 +110,9 @@ func TestICMPCheckerCheckResolveError(t *testing.T) {
 	checker := &ICMPChecker{
 		name:        "ResolveErrorChecker",
 		address:     "invalid-host",
-		protocol:    mockProtocol,
+		protocolFor: fixedProtocol(mockProtocol),
 		readTimeout: 2 * time.Second,
+		listeners:   newICMPListeners(),
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -137,8 +154,9 @@ func TestICMPCheckerCheckWriteError(t *testing.T) {
 	checker := &ICMPChecker{
 		name:        "WriteErrorChecker",
 		address:     testutils.LocalhostIPv4,
-		protocol:    mockProtocol,
+		protocolFor: fixedProtocol(mockProtocol),
 		readTimeout: 2 * time.Second,
+		listeners:   newICMPListeners(),
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -168,9 +186,10 @@ func TestICMPCheckerCheckListenPacketError(t *testing.T) {
 	checker := &ICMPChecker{
 		name:         "ListenPacketErrorChecker",
 		address:      testutils.LocalhostIPv4,
-		protocol:     mockProtocol,
+		protocolFor:  fixedProtocol(mockProtocol),
 		readTimeout:  2 * time.Second,
 		writeTimeout: 2 * time.Second,
+		listeners:    newICMPListeners(),
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -203,9 +222,10 @@ func TestICMPCheckerCheckMakeRequestError(t *testing.T) {
 	checker := &ICMPChecker{
 		name:         "WriteDeadlineErrorChecker",
 		address:      testutils.LocalhostIPv4,
-		protocol:     mockProtocol,
+		protocolFor:  fixedProtocol(mockProtocol),
 		readTimeout:  2 * time.Second,
 		writeTimeout: 2 * time.Second,
+		listeners:    newICMPListeners(),
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -239,9 +259,10 @@ func TestICMPCheckerCheckWriteDeadlineError(t *testing.T) {
 	checker := &ICMPChecker{
 		name:         "WriteDeadlineErrorChecker",
 		address:      testutils.LocalhostIPv4,
-		protocol:     mockProtocol,
+		protocolFor:  fixedProtocol(mockProtocol),
 		readTimeout:  2 * time.Second,
 		writeTimeout: 2 * time.Second,
+		listeners:    newICMPListeners(),
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -275,8 +296,9 @@ func TestICMPCheckerCheckReadError(t *testing.T) {
 	checker := &ICMPChecker{
 		name:        "ReadErrorChecker",
 		address:     testutils.LocalhostIPv4,
-		protocol:    mockProtocol,
+		protocolFor: fixedProtocol(mockProtocol),
 		readTimeout: 2 * time.Second,
+		listeners:   newICMPListeners(),
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -313,8 +335,9 @@ func TestICMPCheckerSetWriteDeadlineError(t *testing.T) {
 	checker := &ICMPChecker{
 		name:        "SetWriteDeadlineErrorChecker",
 		address:     testutils.LocalhostIPv4,
-		protocol:    mockProtocol,
+		protocolFor: fixedProtocol(mockProtocol),
 		readTimeout: 2 * time.Second,
+		listeners:   newICMPListeners(),
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -325,68 +348,21 @@ func TestICMPCheckerSetWriteDeadlineError(t *testing.T) {
 	assert.EqualError(t, err, "failed to set write deadline: mock write deadline error")
 }
 
-// TestICMPCheckerSetReadDeadlineError verifies the expected behavior.
-func TestICMPCheckerSetReadDeadlineError(t *testing.T) {
-	t.Parallel()
-
-	mockProtocol := &testutils.MockProtocol{
-		MakeRequestFunc: func(id, seq uint16) ([]byte, error) {
-			return []byte{}, nil
-		},
-		ValidateReplyFunc: func(reply []byte, id, seq uint16) error {
-			return nil
-		},
-		NetworkFunc: func() string {
-			return icmpv4Network
-		},
-		ListenPacketFunc: func(ctx context.Context, network, address string) (net.PacketConn, error) {
-			return &testutils.MockPacketConn{
-				SetReadDeadlineFunc: func(t time.Time) error {
-					return fmt.Errorf("mock write deadline error")
-				},
-			}, nil
-		},
-	}
-
-	checker := &ICMPChecker{
-		name:        "SetReadDeadlineErrorChecker",
-		address:     testutils.LocalhostIPv4,
-		protocol:    mockProtocol,
-		readTimeout: 2 * time.Second,
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
-
-	err := checker.Check(ctx)
-	require.Error(t, err)
-	assert.EqualError(t, err, "failed to set read deadline: mock write deadline error")
-}
-
-// TestICMPCheckerValidateReplyError verifies the expected behavior.
+// TestICMPCheckerValidateReplyError tests replies that do not answer the check are ignored.
 func TestICMPCheckerValidateReplyError(t *testing.T) {
 	t.Parallel()
 
-	mockProtocol := &testutils.MockProtocol{
-		MakeRequestFunc: func(id, seq uint16) ([]byte, error) {
-			return []byte{}, nil
-		},
-		ValidateReplyFunc: func(reply []byte, id, seq uint16) error {
-			return fmt.Errorf("mock validation error")
-		},
-		NetworkFunc: func() string {
-			return icmpv4Network
-		},
-		ListenPacketFunc: func(ctx context.Context, network, address string) (net.PacketConn, error) {
-			return &testutils.MockPacketConn{}, nil
-		},
+	mockProtocol := echoProtocol(echoConn(nil))
+	mockProtocol.ValidateReplyFunc = func(reply []byte, id, seq uint16) error {
+		return fmt.Errorf("mock validation error")
 	}
 
 	checker := &ICMPChecker{
 		name:        "ValidateReplyErrorChecker",
 		address:     testutils.LocalhostIPv4,
-		protocol:    mockProtocol,
-		readTimeout: 2 * time.Second,
+		protocolFor: fixedProtocol(mockProtocol),
+		readTimeout: 100 * time.Millisecond,
+		listeners:   newICMPListeners(),
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -394,5 +370,6 @@ func TestICMPCheckerValidateReplyError(t *testing.T) {
 
 	err := checker.Check(ctx)
 	require.Error(t, err)
-	assert.EqualError(t, err, "failed to validate ICMP reply: mock validation error")
+	assert.EqualError(t, err, "no ICMP reply received (0/1 received)")
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
 }
@@ -0,0 +1,33 @@
+package checker
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// setDontFragment disables fragmentation of outgoing packets, so requests above the path MTU fail.
+func setDontFragment(conn net.PacketConn, ipv6 bool) error {
+	sc, ok := conn.(syscall.Conn)
+	if !ok {
+		return errors.New("socket does not expose its file descriptor")
+	}
+	raw, err := sc.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	level, opt, value := syscall.IPPROTO_IP, syscall.IP_MTU_DISCOVER, syscall.IP_PMTUDISC_DO
+	if ipv6 {
+		level, opt, value = syscall.IPPROTO_IPV6, syscall.IPV6_MTU_DISCOVER, syscall.IPV6_PMTUDISC_DO
+	}
+
+	var sockErr error
+	if err := raw.Control(func(fd uintptr) {
+		sockErr = syscall.SetsockoptInt(int(fd), level, opt, value)
+	}); err != nil {
+		return err
+	}
+
+	return sockErr
+}
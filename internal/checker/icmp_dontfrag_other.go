@@ -0,0 +1,15 @@
+//go:build !linux
+
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"runtime"
+)
+
+// setDontFragment is only implemented on Linux.
+func setDontFragment(_ net.PacketConn, _ bool) error {
+	return fmt.Errorf("don't-fragment is not supported on %s: %w", runtime.GOOS, errors.ErrUnsupported)
+}
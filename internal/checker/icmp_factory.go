@@ -15,8 +15,34 @@ const (
 	icmpv6ProtocolNumber int    = 58
 	icmpv4Network        string = "ip4:icmp"
 	icmpv6Network        string = "ip6:ipv6-icmp"
+	icmpv4DgramNetwork   string = "udp4"
+	icmpv6DgramNetwork   string = "udp6"
 )
 
+// IPFamily restricts which address family a hostname is resolved to.
+type IPFamily string
+
+const (
+	IPFamilyAny IPFamily = "any" // IPFamilyAny uses the first resolved address of either family.
+	IPFamily4   IPFamily = "4"
+	IPFamily6   IPFamily = "6"
+)
+
+// String returns the user-facing family value.
+func (f IPFamily) String() string { return string(f) }
+
+// network returns the resolver network for the family.
+func (f IPFamily) network() string {
+	switch f {
+	case IPFamily4:
+		return "ip4"
+	case IPFamily6:
+		return "ip6"
+	default:
+		return "ip"
+	}
+}
+
 // Protocol defines an interface for ICMP-based diagnostics, abstracting ICMPv4 and ICMPv6 behavior.
 type Protocol interface {
 	// MakeRequest creates an ICMP echo request message with the specified identifier and sequence number.
@@ -25,6 +51,9 @@ type Protocol interface {
 	// ValidateReply verifies that an ICMP echo reply message matches the expected identifier and sequence number.
 	// Returns an error if the reply is invalid, such as a mismatch in identifier, sequence number, or unexpected message type.
 	ValidateReply(reply []byte, identifier, sequence uint16) error
+	// ParseReply returns the identifier and sequence number of the echo request an ICMP message answers.
+	// Returns an error if the message is neither an echo reply nor an error message quoting an echo request.
+	ParseReply(reply []byte) (identifier, sequence uint16, err error)
 	// Network returns the network type string to be used for listening to ICMP packets, which typically indicates the IP
 	// protocol version (e.g., "ip4:icmp" for IPv4 ICMP or "ip6:ipv6-icmp" for IPv6 ICMP).
 	Network() string
@@ -33,27 +62,49 @@ type Protocol interface {
 	ListenPacket(ctx context.Context, network, address string) (net.PacketConn, error)
 }
 
-// newProtocol initializes a protocol based on the given address.
-func newProtocol(address string) (Protocol, error) {
-	ip := net.ParseIP(address)
-	if ip == nil {
-		// If the address is not an IP, try resolving it as a domain name
-		ips, err := net.LookupIP(address)
-		if err != nil || len(ips) == 0 {
-			return nil, fmt.Errorf("invalid or unresolvable address: %s", address)
-		}
-		ip = ips[0] // Use the first resolved IP address
-	}
+// icmpOptions are the socket type and packet options used to ping a target.
+type icmpOptions struct {
+	datagram     bool // datagram uses a ping socket instead of a raw socket.
+	size         int  // size is the echo payload size in bytes. Zero uses the default payload.
+	ttl          int  // ttl is the IPv4 TTL or IPv6 hop limit. Zero uses the system default.
+	dontFragment bool
+	source       sourceBinding // source is the local address and interface. The address is passed to ListenPacket.
+}
 
+// socketKey identifies the sockets that can be shared by checks with these options.
+func (o icmpOptions) socketKey(network string) string {
+	return fmt.Sprintf("%s ttl=%d df=%t %s", network, o.ttl, o.dontFragment, o.source)
+}
+
+// protocolForIP returns the ICMP protocol matching the address family of ip.
+// Datagram protocols use unprivileged ping sockets instead of raw sockets.
+func protocolForIP(ip net.IP, opts icmpOptions) Protocol {
 	if ip.To16() != nil && ip.To4() == nil {
-		return &ICMPv6{}, nil
+		return &ICMPv6{icmpOptions: opts}
+	}
+
+	return &ICMPv4{icmpOptions: opts}
+}
+
+// isDatagramNetwork reports whether network is a ping socket network.
+func isDatagramNetwork(network string) bool {
+	return network == icmpv4DgramNetwork || network == icmpv6DgramNetwork
+}
+
+// listenICMP opens a raw ICMP socket or, for datagram networks, an unprivileged ping socket.
+func listenICMP(ctx context.Context, network, address string) (net.PacketConn, error) {
+	if isDatagramNetwork(network) {
+		// net.ListenPacket would open a UDP socket for "udp4" and "udp6".
+		return icmp.ListenPacket(network, address)
 	}
 
-	return &ICMPv4{}, nil
+	var lc net.ListenConfig
+	return lc.ListenPacket(ctx, network, address)
 }
 
 // ICMPv4 implements the Protocol interface for IPv4 ICMP.
 type ICMPv4 struct {
+	icmpOptions
 	conn net.PacketConn
 }
 
@@ -62,7 +113,7 @@ func (p *ICMPv4) MakeRequest(identifier, sequence uint16) ([]byte, error) {
 	body := &icmp.Echo{
 		ID:   int(identifier),
 		Seq:  int(sequence),
-		Data: []byte("HELLO-R-U-THERE"),
+		Data: echoPayload(p.size),
 	}
 	msg := icmp.Message{
 		Type: ipv4.ICMPTypeEcho,
@@ -74,40 +125,79 @@ func (p *ICMPv4) MakeRequest(identifier, sequence uint16) ([]byte, error) {
 }
 
 // ValidateReply validates an ICMP echo reply message.
+// Error messages answering the request, such as destination unreachable, are returned as *ICMPMessageError.
 func (p *ICMPv4) ValidateReply(reply []byte, identifier, sequence uint16) error {
-	parsedMsg, err := icmp.ParseMessage(icmpv4ProtocolNumber, reply)
+	parsedMsg, id, seq, err := parseEcho("ICMPv4", icmpv4ProtocolNumber, reply)
 	if err != nil {
-		return fmt.Errorf("failed to parse ICMPv4 message: %w", err)
-	}
-
-	if parsedMsg.Type != ipv4.ICMPTypeEchoReply {
-		return fmt.Errorf("unexpected ICMPv4 message type: %v", parsedMsg.Type)
+		return err
 	}
 
-	body, ok := parsedMsg.Body.(*icmp.Echo)
-	if !ok || body.ID != int(identifier) || body.Seq != int(sequence) {
+	// The kernel replaces the identifier of ping sockets with the local port and only delivers matching replies.
+	if (!p.datagram && id != identifier) || seq != sequence {
 		return fmt.Errorf("identifier or sequence mismatch")
 	}
 
-	return nil
+	return messageError("ICMPv4", parsedMsg, reply)
+}
+
+// ParseReply returns the identifier and sequence number of the echo request an ICMP message answers.
+func (p *ICMPv4) ParseReply(reply []byte) (identifier, sequence uint16, err error) {
+	_, identifier, sequence, err = parseEcho("ICMPv4", icmpv4ProtocolNumber, reply)
+	return identifier, sequence, err
 }
 
 // Network returns the network type for the ICMP protocol.
-func (p *ICMPv4) Network() string { return icmpv4Network }
+func (p *ICMPv4) Network() string {
+	if p.datagram {
+		return icmpv4DgramNetwork
+	}
+	return icmpv4Network
+}
 
-// ListenPacket creates a new ICMPv4 packet connection.
+// ListenPacket creates a new ICMPv4 packet connection with the configured TTL and don't-fragment setting.
 func (p *ICMPv4) ListenPacket(ctx context.Context, network, address string) (net.PacketConn, error) {
-	var lc net.ListenConfig
-	conn, err := lc.ListenPacket(ctx, network, address)
+	conn, err := listenICMP(ctx, network, address)
 	if err != nil {
 		return nil, fmt.Errorf("failed to listen for ICMP packets: %w", err)
 	}
+	if err := p.setSocketOptions(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
 	p.conn = conn
 	return conn, nil
 }
 
+// setSocketOptions applies the TTL, don't-fragment setting and interface binding to conn.
+func (p *ICMPv4) setSocketOptions(conn net.PacketConn) error {
+	pc, ok := conn.(*icmp.PacketConn)
+	var ipConn *ipv4.PacketConn
+	if ok {
+		ipConn = pc.IPv4PacketConn()
+	} else {
+		ipConn = ipv4.NewPacketConn(conn)
+	}
+
+	if p.ttl > 0 {
+		if err := ipConn.SetTTL(p.ttl); err != nil {
+			return fmt.Errorf("failed to set TTL: %w", err)
+		}
+	}
+	if p.dontFragment {
+		if err := setDontFragment(ipConn.PacketConn, false); err != nil {
+			return fmt.Errorf("failed to set don't-fragment: %w", err)
+		}
+	}
+	if err := p.source.bindPacketConn(ipConn.PacketConn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // ICMPv6 implements the Protocol interface for IPv6 ICMP.
 type ICMPv6 struct {
+	icmpOptions
 	conn net.PacketConn
 }
 
@@ -116,7 +206,7 @@ func (p *ICMPv6) MakeRequest(identifier, sequence uint16) ([]byte, error) {
 	body := &icmp.Echo{
 		ID:   int(identifier),
 		Seq:  int(sequence),
-		Data: []byte("HELLO-R-U-THERE"),
+		Data: echoPayload(p.size),
 	}
 	msg := icmp.Message{
 		Type: ipv6.ICMPTypeEchoRequest,
@@ -128,34 +218,72 @@ func (p *ICMPv6) MakeRequest(identifier, sequence uint16) ([]byte, error) {
 }
 
 // ValidateReply validates an ICMP echo reply message.
+// Error messages answering the request, such as destination unreachable, are returned as *ICMPMessageError.
 func (p *ICMPv6) ValidateReply(reply []byte, identifier, sequence uint16) error {
-	parsedMsg, err := icmp.ParseMessage(icmpv6ProtocolNumber, reply)
+	parsedMsg, id, seq, err := parseEcho("ICMPv6", icmpv6ProtocolNumber, reply)
 	if err != nil {
-		return fmt.Errorf("failed to parse ICMPv6 message: %w", err)
-	}
-
-	if parsedMsg.Type != ipv6.ICMPTypeEchoReply {
-		return fmt.Errorf("unexpected ICMPv6 message type: %v", parsedMsg.Type)
+		return err
 	}
 
-	body, ok := parsedMsg.Body.(*icmp.Echo)
-	if !ok || body.ID != int(identifier) || body.Seq != int(sequence) {
+	// The kernel replaces the identifier of ping sockets with the local port and only delivers matching replies.
+	if (!p.datagram && id != identifier) || seq != sequence {
 		return fmt.Errorf("identifier or sequence mismatch")
 	}
 
-	return nil
+	return messageError("ICMPv6", parsedMsg, reply)
+}
+
+// ParseReply returns the identifier and sequence number of the echo request an ICMP message answers.
+func (p *ICMPv6) ParseReply(reply []byte) (identifier, sequence uint16, err error) {
+	_, identifier, sequence, err = parseEcho("ICMPv6", icmpv6ProtocolNumber, reply)
+	return identifier, sequence, err
 }
 
 // Network returns the network type for the ICMP protocol.
-func (p *ICMPv6) Network() string { return icmpv6Network }
+func (p *ICMPv6) Network() string {
+	if p.datagram {
+		return icmpv6DgramNetwork
+	}
+	return icmpv6Network
+}
 
-// ListenPacket creates a new ICMPv6 packet connection.
+// ListenPacket creates a new ICMPv6 packet connection with the configured hop limit and don't-fragment setting.
 func (p *ICMPv6) ListenPacket(ctx context.Context, network, address string) (net.PacketConn, error) {
-	var lc net.ListenConfig
-	conn, err := lc.ListenPacket(ctx, network, address)
+	conn, err := listenICMP(ctx, network, address)
 	if err != nil {
 		return nil, fmt.Errorf("failed to listen for ICMP packets: %w", err)
 	}
+	if err := p.setSocketOptions(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
 	p.conn = conn
 	return p.conn, nil
 }
+
+// setSocketOptions applies the hop limit, don't-fragment setting and interface binding to conn.
+func (p *ICMPv6) setSocketOptions(conn net.PacketConn) error {
+	pc, ok := conn.(*icmp.PacketConn)
+	var ipConn *ipv6.PacketConn
+	if ok {
+		ipConn = pc.IPv6PacketConn()
+	} else {
+		ipConn = ipv6.NewPacketConn(conn)
+	}
+
+	if p.ttl > 0 {
+		if err := ipConn.SetHopLimit(p.ttl); err != nil {
+			return fmt.Errorf("failed to set hop limit: %w", err)
+		}
+	}
+	if p.dontFragment {
+		if err := setDontFragment(ipConn.PacketConn, true); err != nil {
+			return fmt.Errorf("failed to set don't-fragment: %w", err)
+		}
+	}
+	if err := p.source.bindPacketConn(ipConn.PacketConn); err != nil {
+		return err
+	}
+
+	return nil
+}
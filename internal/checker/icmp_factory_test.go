@@ -34,49 +34,24 @@ func isPermissionError(t *testing.T, err error) bool {
 	return strings.Contains(s, "operation not permitted") || strings.Contains(s, "permission denied")
 }
 
-// TestNewProtocol verifies the expected behavior.
-func TestNewProtocol(t *testing.T) {
+// TestProtocolForIP verifies the protocol is picked by the address family.
+func TestProtocolForIP(t *testing.T) {
 	t.Parallel()
 
-	t.Run("Valid IPv4 Address", func(t *testing.T) {
-		t.Parallel()
-
-		protocol, err := newProtocol("192.168.1.1")
-		require.NoError(t, err)
-
-		if _, ok := protocol.(*ICMPv4); !ok {
-			t.Fatalf("expected ICMPv4 protocol, got %T", protocol)
-		}
-	})
-
-	t.Run("Valid IPv6 Address", func(t *testing.T) {
-		t.Parallel()
-
-		protocol, err := newProtocol("2001:db8::1")
-		require.NoError(t, err)
-
-		if _, ok := protocol.(*ICMPv6); !ok {
-			t.Fatalf("expected ICMPv6 protocol, got %T", protocol)
-		}
-	})
-
-	t.Run("Unresolvable Address", func(t *testing.T) {
-		t.Parallel()
-
-		_, err := newProtocol("invalid.domain")
-
-		require.Error(t, err)
-		assert.EqualError(t, err, "invalid or unresolvable address: invalid.domain")
-	})
-
-	t.Run("Unsupported IP Address", func(t *testing.T) {
-		t.Parallel()
+	assert.Equal(t, icmpv4Network, protocolForIP(net.ParseIP("192.168.1.1"), icmpOptions{}).Network())
+	assert.Equal(t, icmpv4Network, protocolForIP(net.ParseIP("::ffff:192.168.1.1"), icmpOptions{}).Network())
+	assert.Equal(t, icmpv6Network, protocolForIP(net.ParseIP("2001:db8::1"), icmpOptions{}).Network())
+	assert.Equal(t, icmpv4DgramNetwork, protocolForIP(net.ParseIP("192.168.1.1"), icmpOptions{datagram: true}).Network())
+	assert.Equal(t, icmpv6DgramNetwork, protocolForIP(net.ParseIP("2001:db8::1"), icmpOptions{datagram: true}).Network())
+}
 
-		_, err := newProtocol("300.300.300.300")
+// TestIPFamilyNetwork verifies each IP family maps to a resolver network.
+func TestIPFamilyNetwork(t *testing.T) {
+	t.Parallel()
 
-		require.Error(t, err)
-		assert.EqualError(t, err, "invalid or unresolvable address: 300.300.300.300")
-	})
+	assert.Equal(t, "ip", IPFamilyAny.network())
+	assert.Equal(t, "ip4", IPFamily4.network())
+	assert.Equal(t, "ip6", IPFamily6.network())
 }
 
 // TestICMPv4MakeRequest verifies the expected behavior.
@@ -0,0 +1,202 @@
+package checker
+
+import (
+	"context"
+	"net"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// icmpID is the echo identifier of all requests sent by this process.
+var icmpID = uint16(os.Getpid() & 0xffff)
+
+// defaultICMPListeners is shared by all ICMP checkers of the process.
+var defaultICMPListeners = newICMPListeners()
+
+// icmpReply is an echo reply, or a read error, dispatched to a waiting check.
+type icmpReply struct {
+	seq      uint16
+	data     []byte
+	from     net.Addr
+	received time.Time
+	err      error
+}
+
+// icmpListeners shares one ICMP socket per network and socket options between concurrent checks.
+type icmpListeners struct {
+	mu        sync.Mutex
+	listeners map[string]*icmpListener
+}
+
+// newICMPListeners creates an empty listener set.
+func newICMPListeners() *icmpListeners {
+	return &icmpListeners{listeners: make(map[string]*icmpListener)}
+}
+
+// acquire returns the listener for key and opens its socket on address with protocol on first use.
+// Every successful acquire must be paired with a release.
+func (ls *icmpListeners) acquire(ctx context.Context, key string, protocol Protocol, address string) (*icmpListener, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if l, ok := ls.listeners[key]; ok {
+		l.refs++
+		return l, nil
+	}
+
+	conn, err := protocol.ListenPacket(ctx, protocol.Network(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &icmpListener{
+		owner:    ls,
+		key:      key,
+		protocol: protocol,
+		conn:     conn,
+		refs:     1,
+		waiters:  make(map[uint16]chan<- icmpReply),
+	}
+	ls.listeners[key] = l
+	go l.read()
+
+	return l, nil
+}
+
+// release drops a reference and closes the socket once no check uses it anymore.
+func (ls *icmpListeners) release(l *icmpListener) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	l.refs--
+	if l.refs > 0 {
+		return
+	}
+	if ls.listeners[l.key] == l {
+		delete(ls.listeners, l.key)
+	}
+	l.close()
+}
+
+// forget removes a broken listener so the next acquire opens a new socket.
+func (ls *icmpListeners) forget(l *icmpListener) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if ls.listeners[l.key] == l {
+		delete(ls.listeners, l.key)
+	}
+}
+
+// icmpListener reads all replies of one ICMP socket and dispatches them by sequence number.
+type icmpListener struct {
+	owner    *icmpListeners
+	key      string
+	protocol Protocol
+	conn     net.PacketConn
+	refs     int // refs is guarded by owner.mu.
+
+	mu      sync.Mutex
+	waiters map[uint16]chan<- icmpReply
+	closed  bool
+	err     error // err is the read error that stopped the reader.
+}
+
+// register delivers replies with sequence number seq to ch until unregister is called.
+// If the reader already failed, its error is delivered instead.
+func (l *icmpListener) register(seq uint16, ch chan<- icmpReply) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.err != nil {
+		select {
+		case ch <- icmpReply{seq: seq, err: l.err}:
+		default:
+		}
+		return
+	}
+	l.waiters[seq] = ch
+}
+
+// unregister stops delivering replies with sequence number seq.
+func (l *icmpListener) unregister(seq uint16) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.waiters, seq)
+}
+
+// read dispatches replies until the socket is closed or fails.
+// Packets that are not echo replies to this process are ignored.
+func (l *icmpListener) read() {
+	buf := make([]byte, MaxICMPPayloadSize+64)
+	for {
+		n, from, err := l.conn.ReadFrom(buf)
+		received := time.Now()
+		if err != nil {
+			l.fail(err)
+			return
+		}
+
+		id, seq, err := l.protocol.ParseReply(buf[:n])
+		// The kernel replaces the identifier of ping sockets and only delivers their own replies.
+		if err != nil || (!isDatagramNetwork(l.protocol.Network()) && id != icmpID) {
+			if l.isClosed() {
+				return
+			}
+			continue
+		}
+
+		l.mu.Lock()
+		if l.closed {
+			l.mu.Unlock()
+			return
+		}
+		if ch, ok := l.waiters[seq]; ok {
+			select {
+			case ch <- icmpReply{seq: seq, data: slices.Clone(buf[:n]), from: from, received: received}:
+			default: // The check already has this reply, e.g. a duplicate.
+			}
+		}
+		l.mu.Unlock()
+	}
+}
+
+// fail passes a read error to all waiting checks unless the socket was closed on purpose.
+func (l *icmpListener) fail(err error) {
+	l.mu.Lock()
+	closed := l.closed
+	if !closed {
+		l.err = err
+		for seq, ch := range l.waiters {
+			select {
+			case ch <- icmpReply{seq: seq, err: err}:
+			default:
+			}
+		}
+	}
+	l.mu.Unlock()
+
+	if !closed {
+		l.owner.forget(l)
+	}
+}
+
+// isClosed reports whether the socket was closed on purpose.
+func (l *icmpListener) isClosed() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.closed
+}
+
+// close closes the socket and stops the reader.
+func (l *icmpListener) close() {
+	l.mu.Lock()
+	l.closed = true
+	l.mu.Unlock()
+
+	_ = l.conn.Close()
+}
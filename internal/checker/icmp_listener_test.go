@@ -0,0 +1,218 @@
+package checker
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/containeroo/never/internal/testutils"
+)
+
+// echoConn returns a packet connection that answers every written request except the dropped indexes.
+// ReadFrom blocks until a reply is queued or the connection is closed.
+func echoConn(drop map[int]bool) *testutils.MockPacketConn {
+	replies := make(chan []byte, 64)
+	closed := make(chan struct{})
+	var once sync.Once
+	var sent atomic.Int32
+
+	return &testutils.MockPacketConn{
+		WriteToFunc: func(b []byte, addr net.Addr) (int, error) {
+			if !drop[int(sent.Add(1)-1)] {
+				replies <- append([]byte(nil), b...)
+			}
+			return len(b), nil
+		},
+		ReadFromFunc: func(b []byte) (int, net.Addr, error) {
+			select {
+			case reply := <-replies:
+				return copy(b, reply), nil, nil
+			case <-closed:
+				return 0, nil, net.ErrClosed
+			}
+		},
+		CloseFunc: func() error {
+			once.Do(func() { close(closed) })
+			return nil
+		},
+	}
+}
+
+// encodeEcho encodes an identifier and sequence number the way echoProtocol expects them.
+func encodeEcho(id, seq uint16) []byte {
+	return binary.BigEndian.AppendUint16(binary.BigEndian.AppendUint16(nil, id), seq)
+}
+
+// echoProtocol returns a protocol whose requests carry only the identifier and sequence number.
+func echoProtocol(conn net.PacketConn) *testutils.MockProtocol {
+	return &testutils.MockProtocol{
+		MakeRequestFunc: func(id, seq uint16) ([]byte, error) { return encodeEcho(id, seq), nil },
+		ParseReplyFunc: func(reply []byte) (uint16, uint16, error) {
+			if len(reply) != 4 {
+				return 0, 0, errors.New("not an echo reply")
+			}
+			return binary.BigEndian.Uint16(reply), binary.BigEndian.Uint16(reply[2:]), nil
+		},
+		ValidateReplyFunc: func(reply []byte, id, seq uint16) error {
+			if len(reply) != 4 || binary.BigEndian.Uint16(reply[2:]) != seq {
+				return assert.AnError
+			}
+			return nil
+		},
+		NetworkFunc:      func() string { return icmpv4Network },
+		ListenPacketFunc: func(ctx context.Context, network, address string) (net.PacketConn, error) { return conn, nil },
+	}
+}
+
+// TestICMPListenerIgnoresForeignPackets verifies replies to other processes and non-echo packets are dropped.
+func TestICMPListenerIgnoresForeignPackets(t *testing.T) {
+	t.Parallel()
+
+	conn := echoConn(nil)
+	listeners := newICMPListeners()
+	l, err := listeners.acquire(context.Background(), icmpv4Network, echoProtocol(conn), "")
+	require.NoError(t, err)
+	defer listeners.release(l)
+
+	replies := make(chan icmpReply, 1)
+	l.register(7, replies)
+
+	_, _ = conn.WriteTo([]byte("garbage"), nil)
+	_, _ = conn.WriteTo(encodeEcho(icmpID+1, 7), nil)
+	_, _ = conn.WriteTo(encodeEcho(icmpID, 8), nil)
+	_, _ = conn.WriteTo(encodeEcho(icmpID, 7), nil)
+
+	select {
+	case r := <-replies:
+		require.NoError(t, r.err)
+		assert.Equal(t, uint16(7), r.seq)
+		assert.Equal(t, encodeEcho(icmpID, 7), r.data)
+	case <-time.After(time.Second):
+		t.Fatal("reply was not dispatched")
+	}
+
+	select {
+	case r := <-replies:
+		t.Fatalf("unexpected reply dispatched: %+v", r)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestICMPListenerShared verifies concurrent checks share one socket and each receives its own replies.
+func TestICMPListenerShared(t *testing.T) {
+	t.Parallel()
+
+	var opened atomic.Int32
+	conn := echoConn(nil)
+	protocol := echoProtocol(conn)
+	protocol.ListenPacketFunc = func(ctx context.Context, network, address string) (net.PacketConn, error) {
+		opened.Add(1)
+		return conn, nil
+	}
+
+	// Hold the socket open so every check uses the same one.
+	listeners := newICMPListeners()
+	held, err := listeners.acquire(context.Background(), icmpOptions{}.socketKey(icmpv4Network), protocol, "")
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		checker := &ICMPChecker{
+			name:        "Shared",
+			address:     testutils.LocalhostIPv4,
+			protocolFor: fixedProtocol(protocol),
+			readTimeout: time.Second,
+			count:       3,
+			listeners:   listeners,
+		}
+		wg.Go(func() { errs[i] = checker.Check(context.Background()) })
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), opened.Load())
+
+	listeners.release(held)
+	assert.True(t, held.isClosed(), "socket must be closed once no check uses it")
+}
+
+// TestICMPListenerRefCount verifies the socket is reused while acquired and reopened after release.
+func TestICMPListenerRefCount(t *testing.T) {
+	t.Parallel()
+
+	var opened atomic.Int32
+	protocol := echoProtocol(nil)
+	protocol.ListenPacketFunc = func(ctx context.Context, network, address string) (net.PacketConn, error) {
+		opened.Add(1)
+		return echoConn(nil), nil
+	}
+
+	listeners := newICMPListeners()
+	first, err := listeners.acquire(context.Background(), icmpv4Network, protocol, "")
+	require.NoError(t, err)
+	second, err := listeners.acquire(context.Background(), icmpv4Network, protocol, "")
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, int32(1), opened.Load())
+
+	listeners.release(first)
+	assert.False(t, first.isClosed())
+	listeners.release(second)
+	assert.True(t, first.isClosed())
+
+	third, err := listeners.acquire(context.Background(), icmpv4Network, protocol, "")
+	require.NoError(t, err)
+	defer listeners.release(third)
+	assert.NotSame(t, first, third)
+	assert.Equal(t, int32(2), opened.Load())
+}
+
+// TestICMPListenerReadError verifies a read error reaches every waiting check and the broken socket is replaced.
+func TestICMPListenerReadError(t *testing.T) {
+	t.Parallel()
+
+	readErr := errors.New("mock read error")
+	release := make(chan struct{})
+	conn := &testutils.MockPacketConn{
+		ReadFromFunc: func(b []byte) (int, net.Addr, error) {
+			<-release
+			return 0, nil, readErr
+		},
+	}
+
+	listeners := newICMPListeners()
+	l, err := listeners.acquire(context.Background(), icmpv4Network, echoProtocol(conn), "")
+	require.NoError(t, err)
+	defer listeners.release(l)
+
+	replies := make(chan icmpReply, 1)
+	l.register(1, replies)
+	close(release)
+
+	select {
+	case r := <-replies:
+		require.ErrorIs(t, r.err, readErr)
+	case <-time.After(time.Second):
+		t.Fatal("read error was not dispatched")
+	}
+
+	// Checks registering after the failure get the error as well.
+	late := make(chan icmpReply, 1)
+	l.register(2, late)
+	require.ErrorIs(t, (<-late).err, readErr)
+
+	listeners.mu.Lock()
+	defer listeners.mu.Unlock()
+	assert.NotContains(t, listeners.listeners, icmpv4Network)
+}
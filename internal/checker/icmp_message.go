@@ -0,0 +1,185 @@
+package checker
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+// defaultICMPPayload is the echo payload used when no size is configured.
+const defaultICMPPayload = "HELLO-R-U-THERE"
+
+// MaxICMPPayloadSize is the largest echo payload in bytes that fits into an IPv4 packet.
+const MaxICMPPayloadSize = 65507
+
+var (
+	// ErrNetUnreachable is reported when no router knows a route to the destination network.
+	ErrNetUnreachable = errors.New("network unreachable")
+	// ErrHostUnreachable is reported when the last router cannot reach the destination host.
+	ErrHostUnreachable = errors.New("host unreachable")
+	// ErrAdminProhibited is reported when a firewall rejected the request.
+	ErrAdminProhibited = errors.New("administratively prohibited")
+	// ErrDestinationUnreachable is reported for the remaining destination unreachable codes.
+	ErrDestinationUnreachable = errors.New("destination unreachable")
+	// ErrFragmentationNeeded is reported when a request exceeds the path MTU and must not be fragmented.
+	ErrFragmentationNeeded = errors.New("fragmentation needed")
+	// ErrTimeExceeded is reported when the TTL or hop limit of a request expired in transit.
+	ErrTimeExceeded = errors.New("time exceeded")
+)
+
+// ICMPMessageError is returned when an echo request is answered with an ICMP error message instead of a reply.
+type ICMPMessageError struct {
+	Err  error  // Err is one of the ICMP message errors, such as ErrHostUnreachable.
+	Code int    // Code is the code of the ICMP message.
+	From net.IP // From is the router that sent the message.
+	MTU  int    // MTU is the next-hop MTU reported with ErrFragmentationNeeded, if any.
+}
+
+// Error returns the error message naming the sending router.
+func (e *ICMPMessageError) Error() string {
+	msg := e.Err.Error()
+	if errors.Is(e.Err, ErrDestinationUnreachable) {
+		msg += fmt.Sprintf(" (code %d)", e.Code)
+	}
+	if e.From != nil {
+		msg += " from " + e.From.String()
+	}
+	if e.MTU > 0 {
+		msg += fmt.Sprintf(" (next-hop MTU %d)", e.MTU)
+	}
+
+	return msg
+}
+
+// Unwrap returns the kind of the ICMP error message.
+func (e *ICMPMessageError) Unwrap() error { return e.Err }
+
+// icmpv4Unreachable maps ICMPv4 destination unreachable codes (RFC 792, RFC 1812) to errors.
+var icmpv4Unreachable = map[int]error{
+	0:  ErrNetUnreachable,
+	1:  ErrHostUnreachable,
+	4:  ErrFragmentationNeeded,
+	6:  ErrNetUnreachable,
+	7:  ErrHostUnreachable,
+	9:  ErrAdminProhibited,
+	10: ErrAdminProhibited,
+	11: ErrNetUnreachable,
+	12: ErrHostUnreachable,
+	13: ErrAdminProhibited,
+}
+
+// icmpv6Unreachable maps ICMPv6 destination unreachable codes (RFC 4443) to errors.
+var icmpv6Unreachable = map[int]error{
+	0: ErrNetUnreachable,
+	1: ErrAdminProhibited,
+	3: ErrHostUnreachable,
+	5: ErrAdminProhibited,
+	6: ErrAdminProhibited,
+}
+
+// messageError returns the error an ICMP error message reports, or nil for an echo reply.
+func messageError(family string, msg *icmp.Message, reply []byte) error {
+	switch msg.Type {
+	case ipv4.ICMPTypeEchoReply, ipv6.ICMPTypeEchoReply:
+		return nil
+	case ipv4.ICMPTypeDestinationUnreachable:
+		msgErr := &ICMPMessageError{Err: unreachableError(icmpv4Unreachable, msg.Code), Code: msg.Code}
+		if msgErr.Err == ErrFragmentationNeeded {
+			// The next-hop MTU follows the checksum and two unused bytes (RFC 1191).
+			msgErr.MTU = int(binary.BigEndian.Uint16(reply[6:8]))
+		}
+		return msgErr
+	case ipv6.ICMPTypeDestinationUnreachable:
+		return &ICMPMessageError{Err: unreachableError(icmpv6Unreachable, msg.Code), Code: msg.Code}
+	case ipv6.ICMPTypePacketTooBig:
+		return &ICMPMessageError{Err: ErrFragmentationNeeded, MTU: msg.Body.(*icmp.PacketTooBig).MTU}
+	case ipv4.ICMPTypeTimeExceeded, ipv6.ICMPTypeTimeExceeded:
+		return &ICMPMessageError{Err: ErrTimeExceeded, Code: msg.Code}
+	default:
+		return fmt.Errorf("unexpected %s message type: %v", family, msg.Type)
+	}
+}
+
+// unreachableError returns the error for a destination unreachable code.
+func unreachableError(codes map[int]error, code int) error {
+	if err, ok := codes[code]; ok {
+		return err
+	}
+
+	return ErrDestinationUnreachable
+}
+
+// echoPayload returns a payload of size bytes repeating the default payload, or the default payload if size is zero.
+func echoPayload(size int) []byte {
+	if size <= 0 {
+		return []byte(defaultICMPPayload)
+	}
+
+	return []byte(strings.Repeat(defaultICMPPayload, size/len(defaultICMPPayload)+1)[:size])
+}
+
+// parseEcho returns the message and the echo identifier and sequence number it answers.
+// Error messages answer the echo request they quote.
+func parseEcho(family string, proto int, reply []byte) (*icmp.Message, uint16, uint16, error) {
+	msg, err := icmp.ParseMessage(proto, reply)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to parse %s message: %w", family, err)
+	}
+
+	var quoted []byte
+	switch body := msg.Body.(type) {
+	case *icmp.Echo:
+		if msg.Type == ipv4.ICMPTypeEchoReply || msg.Type == ipv6.ICMPTypeEchoReply {
+			return msg, uint16(body.ID), uint16(body.Seq), nil
+		}
+	case *icmp.DstUnreach:
+		quoted = body.Data
+	case *icmp.TimeExceeded:
+		quoted = body.Data
+	case *icmp.PacketTooBig:
+		quoted = body.Data
+	}
+
+	if id, seq, ok := quotedEcho(quoted); ok {
+		return msg, id, seq, nil
+	}
+
+	return nil, 0, 0, fmt.Errorf("unexpected %s message type: %v", family, msg.Type)
+}
+
+// quotedEcho returns the identifier and sequence number of the echo request quoted by an ICMP error message.
+// The quote starts with the IP header of the request, followed by at least 8 bytes of its ICMP message.
+func quotedEcho(quoted []byte) (id, seq uint16, ok bool) {
+	if len(quoted) == 0 {
+		return 0, 0, false
+	}
+
+	var offset int
+	var echoType byte
+	switch quoted[0] >> 4 {
+	case 4:
+		offset = int(quoted[0]&0x0f) * 4
+		echoType = byte(ipv4.ICMPTypeEcho)
+	case 6:
+		const ipv6HeaderLen = 40
+		if len(quoted) < ipv6HeaderLen || quoted[6] != byte(icmpv6ProtocolNumber) {
+			return 0, 0, false
+		}
+		offset = ipv6HeaderLen
+		echoType = byte(ipv6.ICMPTypeEchoRequest)
+	default:
+		return 0, 0, false
+	}
+
+	if len(quoted) < offset+8 || quoted[offset] != echoType {
+		return 0, 0, false
+	}
+
+	return binary.BigEndian.Uint16(quoted[offset+4:]), binary.BigEndian.Uint16(quoted[offset+6:]), true
+}
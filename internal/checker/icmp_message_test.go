@@ -0,0 +1,245 @@
+package checker
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/containeroo/never/internal/testutils"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+// quoteIPv4 returns an IPv4 header followed by the first 8 bytes of request, as quoted by ICMP error messages.
+func quoteIPv4(request []byte) []byte {
+	header := make([]byte, ipv4.HeaderLen)
+	header[0] = 4<<4 | ipv4.HeaderLen/4
+	header[9] = byte(icmpv4ProtocolNumber)
+	return append(header, request[:8]...)
+}
+
+// quoteIPv6 returns an IPv6 header followed by the first 8 bytes of request, as quoted by ICMPv6 error messages.
+func quoteIPv6(request []byte) []byte {
+	header := make([]byte, ipv6.HeaderLen)
+	header[0] = 6 << 4
+	header[6] = byte(icmpv6ProtocolNumber)
+	return append(header, request[:8]...)
+}
+
+// TestEchoPayload verifies payloads of the configured size repeat the default payload.
+func TestEchoPayload(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []byte(defaultICMPPayload), echoPayload(0))
+	assert.Equal(t, []byte("HELLO"), echoPayload(5))
+	assert.Len(t, echoPayload(1472), 1472)
+	assert.Equal(t, []byte(defaultICMPPayload+"HE"), echoPayload(17))
+
+	msg, err := (&ICMPv4{icmpOptions: icmpOptions{size: 1472}}).MakeRequest(1, 1)
+	require.NoError(t, err)
+	assert.Len(t, msg, 1480)
+
+	msg, err = (&ICMPv6{icmpOptions: icmpOptions{size: 1452}}).MakeRequest(1, 1)
+	require.NoError(t, err)
+	assert.Len(t, msg, 1460)
+}
+
+// TestICMPv4ErrorMessages verifies fragmentation needed and time exceeded messages are matched to the quoted request.
+func TestICMPv4ErrorMessages(t *testing.T) {
+	t.Parallel()
+
+	protocol := &ICMPv4{}
+	request, err := protocol.MakeRequest(1234, 7)
+	require.NoError(t, err)
+
+	fragNeeded, err := (&icmp.Message{
+		Type: ipv4.ICMPTypeDestinationUnreachable,
+		Code: 4,
+		Body: &icmp.DstUnreach{Data: quoteIPv4(request)},
+	}).Marshal(nil)
+	require.NoError(t, err)
+	binary.BigEndian.PutUint16(fragNeeded[6:], 1400)
+
+	timeExceeded, err := (&icmp.Message{
+		Type: ipv4.ICMPTypeTimeExceeded,
+		Body: &icmp.TimeExceeded{Data: quoteIPv4(request)},
+	}).Marshal(nil)
+	require.NoError(t, err)
+
+	id, seq, err := protocol.ParseReply(fragNeeded)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(1234), id)
+	assert.Equal(t, uint16(7), seq)
+
+	err = protocol.ValidateReply(fragNeeded, 1234, 7)
+	require.ErrorIs(t, err, ErrFragmentationNeeded)
+	assert.EqualError(t, err, "fragmentation needed (next-hop MTU 1400)")
+
+	err = protocol.ValidateReply(timeExceeded, 1234, 7)
+	require.ErrorIs(t, err, ErrTimeExceeded)
+	assert.EqualError(t, protocol.ValidateReply(timeExceeded, 1234, 8), "identifier or sequence mismatch")
+
+	_, _, err = protocol.ParseReply(request)
+	assert.EqualError(t, err, "unexpected ICMPv4 message type: echo")
+}
+
+// TestICMPv6ErrorMessages verifies packet too big and time exceeded messages are matched to the quoted request.
+func TestICMPv6ErrorMessages(t *testing.T) {
+	t.Parallel()
+
+	protocol := &ICMPv6{}
+	request, err := protocol.MakeRequest(1234, 7)
+	require.NoError(t, err)
+
+	tooBig, err := (&icmp.Message{
+		Type: ipv6.ICMPTypePacketTooBig,
+		Body: &icmp.PacketTooBig{MTU: 1280, Data: quoteIPv6(request)},
+	}).Marshal(nil)
+	require.NoError(t, err)
+
+	timeExceeded, err := (&icmp.Message{
+		Type: ipv6.ICMPTypeTimeExceeded,
+		Body: &icmp.TimeExceeded{Data: quoteIPv6(request)},
+	}).Marshal(nil)
+	require.NoError(t, err)
+
+	err = protocol.ValidateReply(tooBig, 1234, 7)
+	require.ErrorIs(t, err, ErrFragmentationNeeded)
+	assert.EqualError(t, err, "fragmentation needed (next-hop MTU 1280)")
+
+	id, seq, err := protocol.ParseReply(timeExceeded)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(1234), id)
+	assert.Equal(t, uint16(7), seq)
+	require.ErrorIs(t, protocol.ValidateReply(timeExceeded, 1234, 7), ErrTimeExceeded)
+}
+
+// TestICMPUnreachableMessages verifies destination unreachable codes are reported as typed errors.
+func TestICMPUnreachableMessages(t *testing.T) {
+	t.Parallel()
+
+	v4, err := (&ICMPv4{}).MakeRequest(1234, 7)
+	require.NoError(t, err)
+	v6, err := (&ICMPv6{}).MakeRequest(1234, 7)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		protocol Protocol
+		msgType  icmp.Type
+		code     int
+		quote    []byte
+		want     error
+		wantMsg  string
+	}{
+		{name: "IPv4 net unreachable", protocol: &ICMPv4{}, msgType: ipv4.ICMPTypeDestinationUnreachable, code: 0, quote: quoteIPv4(v4), want: ErrNetUnreachable, wantMsg: "network unreachable"},
+		{name: "IPv4 host unreachable", protocol: &ICMPv4{}, msgType: ipv4.ICMPTypeDestinationUnreachable, code: 1, quote: quoteIPv4(v4), want: ErrHostUnreachable, wantMsg: "host unreachable"},
+		{name: "IPv4 admin prohibited", protocol: &ICMPv4{}, msgType: ipv4.ICMPTypeDestinationUnreachable, code: 13, quote: quoteIPv4(v4), want: ErrAdminProhibited, wantMsg: "administratively prohibited"},
+		{name: "IPv4 other code", protocol: &ICMPv4{}, msgType: ipv4.ICMPTypeDestinationUnreachable, code: 3, quote: quoteIPv4(v4), want: ErrDestinationUnreachable, wantMsg: "destination unreachable (code 3)"},
+		{name: "IPv4 reassembly time exceeded", protocol: &ICMPv4{}, msgType: ipv4.ICMPTypeTimeExceeded, code: 1, quote: quoteIPv4(v4), want: ErrTimeExceeded, wantMsg: "time exceeded"},
+		{name: "IPv6 no route", protocol: &ICMPv6{}, msgType: ipv6.ICMPTypeDestinationUnreachable, code: 0, quote: quoteIPv6(v6), want: ErrNetUnreachable, wantMsg: "network unreachable"},
+		{name: "IPv6 address unreachable", protocol: &ICMPv6{}, msgType: ipv6.ICMPTypeDestinationUnreachable, code: 3, quote: quoteIPv6(v6), want: ErrHostUnreachable, wantMsg: "host unreachable"},
+		{name: "IPv6 admin prohibited", protocol: &ICMPv6{}, msgType: ipv6.ICMPTypeDestinationUnreachable, code: 1, quote: quoteIPv6(v6), want: ErrAdminProhibited, wantMsg: "administratively prohibited"},
+		{name: "IPv6 reject route", protocol: &ICMPv6{}, msgType: ipv6.ICMPTypeDestinationUnreachable, code: 6, quote: quoteIPv6(v6), want: ErrAdminProhibited, wantMsg: "administratively prohibited"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var body icmp.MessageBody = &icmp.DstUnreach{Data: tt.quote}
+			if tt.msgType == ipv4.ICMPTypeTimeExceeded {
+				body = &icmp.TimeExceeded{Data: tt.quote}
+			}
+			reply, err := (&icmp.Message{Type: tt.msgType, Code: tt.code, Body: body}).Marshal(nil)
+			require.NoError(t, err)
+
+			id, seq, err := tt.protocol.ParseReply(reply)
+			require.NoError(t, err)
+			assert.Equal(t, uint16(1234), id)
+			assert.Equal(t, uint16(7), seq)
+
+			err = tt.protocol.ValidateReply(reply, 1234, 7)
+			require.ErrorIs(t, err, tt.want)
+			assert.EqualError(t, err, tt.wantMsg)
+
+			var msgErr *ICMPMessageError
+			require.ErrorAs(t, err, &msgErr)
+			assert.Equal(t, tt.code, msgErr.Code)
+		})
+	}
+}
+
+// TestICMPUnreachableForeignQuote verifies error messages quoting another packet are not matched to a request.
+func TestICMPUnreachableForeignQuote(t *testing.T) {
+	t.Parallel()
+
+	// A TCP segment quoted instead of an echo request.
+	quote := quoteIPv4(make([]byte, 8))
+	quote[9] = 6
+	quote[ipv4.HeaderLen] = 0x1f
+	reply, err := (&icmp.Message{Type: ipv4.ICMPTypeDestinationUnreachable, Code: 1, Body: &icmp.DstUnreach{Data: quote}}).Marshal(nil)
+	require.NoError(t, err)
+
+	_, _, err = (&ICMPv4{}).ParseReply(reply)
+	assert.EqualError(t, err, "unexpected ICMPv4 message type: destination unreachable")
+}
+
+// TestICMPCheckerMessageError verifies an ICMP error message fails the attempt and names the sending router.
+func TestICMPCheckerMessageError(t *testing.T) {
+	t.Parallel()
+
+	router := &net.IPAddr{IP: net.ParseIP("192.0.2.1")}
+	conn := echoConn(nil)
+	read := conn.ReadFromFunc
+	conn.ReadFromFunc = func(b []byte) (int, net.Addr, error) {
+		n, _, err := read(b)
+		return n, router, err
+	}
+
+	protocol := echoProtocol(conn)
+	protocol.ValidateReplyFunc = func(reply []byte, id, seq uint16) error {
+		return &ICMPMessageError{Err: ErrHostUnreachable, Code: 1}
+	}
+
+	checker := &ICMPChecker{
+		name:        "Unreachable",
+		address:     testutils.LocalhostIPv4,
+		protocolFor: fixedProtocol(protocol),
+		readTimeout: time.Second,
+		listeners:   newICMPListeners(),
+	}
+
+	err := checker.Check(context.Background())
+	require.ErrorIs(t, err, ErrHostUnreachable)
+	assert.EqualError(t, err, "host unreachable from 192.0.2.1")
+}
+
+// TestICMPv4ListenPacketSocketOptions verifies the TTL and don't-fragment setting are applied to raw sockets.
+func TestICMPv4ListenPacketSocketOptions(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS != "linux" {
+		t.Skip("skipping: don't-fragment is only supported on Linux")
+	}
+
+	protocol := &ICMPv4{icmpOptions: icmpOptions{ttl: 3, dontFragment: true}}
+	conn, err := protocol.ListenPacket(context.Background(), icmpv4Network, "")
+	if isPermissionError(t, err) {
+		t.Skip("skipping: requires raw ICMP privileges (root or CAP_NET_RAW)")
+	}
+	require.NoError(t, err)
+	defer conn.Close() // nolint:errcheck
+
+	ttl, err := ipv4.NewPacketConn(conn).TTL()
+	require.NoError(t, err)
+	assert.Equal(t, 3, ttl)
+}
@@ -0,0 +1,57 @@
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+)
+
+// ICMPPrivileged selects between raw ICMP sockets and unprivileged ping sockets.
+type ICMPPrivileged string
+
+const (
+	// ICMPPrivilegedAuto uses raw sockets and falls back to ping sockets when raw sockets are not permitted.
+	ICMPPrivilegedAuto ICMPPrivileged = "auto"
+	// ICMPPrivilegedTrue uses raw sockets, which need the CAP_NET_RAW capability.
+	ICMPPrivilegedTrue ICMPPrivileged = "true"
+	// ICMPPrivilegedFalse uses ping sockets, which need a group ID within net.ipv4.ping_group_range.
+	ICMPPrivilegedFalse ICMPPrivileged = "false"
+)
+
+// String returns the user-facing mode value.
+func (p ICMPPrivileged) String() string { return string(p) }
+
+// ICMPPermissionError is returned when the ICMP socket of the configured mode may not be opened.
+type ICMPPermissionError struct {
+	Privileged ICMPPrivileged
+	Err        error
+}
+
+// Error returns the error message naming the missing permission.
+func (e *ICMPPermissionError) Error() string {
+	switch e.Privileged {
+	case ICMPPrivilegedTrue:
+		return fmt.Sprintf("raw ICMP sockets need the CAP_NET_RAW capability: %v", e.Err)
+	case ICMPPrivilegedFalse:
+		return fmt.Sprintf("ICMP ping sockets need a group ID within net.ipv4.ping_group_range: %v", e.Err)
+	default:
+		return fmt.Sprintf("ICMP needs the CAP_NET_RAW capability or a group ID within net.ipv4.ping_group_range: %v", e.Err)
+	}
+}
+
+// Unwrap returns the underlying error.
+func (e *ICMPPermissionError) Unwrap() error { return e.Err }
+
+// isPermissionDenied reports whether err was caused by missing socket permissions.
+func isPermissionDenied(err error) bool {
+	return errors.Is(err, syscall.EPERM) || errors.Is(err, syscall.EACCES)
+}
+
+// WithICMPPrivileged sets whether the ICMPChecker uses raw sockets, ping sockets or detects it.
+func WithICMPPrivileged(privileged ICMPPrivileged) Option {
+	return OptionFunc(func(c Checker) {
+		if icmpChecker, ok := c.(*ICMPChecker); ok {
+			icmpChecker.privileged = privileged
+		}
+	})
+}
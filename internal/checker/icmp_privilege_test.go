@@ -0,0 +1,129 @@
+package checker
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/containeroo/never/internal/testutils"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+// socketProtocols returns a protocol selector whose raw and ping sockets fail with the given errors.
+func socketProtocols(rawErr, dgramErr error) func(net.IP, icmpOptions) Protocol {
+	return func(_ net.IP, opts icmpOptions) Protocol {
+		err := rawErr
+		if opts.datagram {
+			err = dgramErr
+		}
+		protocol := echoProtocol(echoConn(nil))
+		protocol.ListenPacketFunc = func(ctx context.Context, network, address string) (net.PacketConn, error) {
+			if err != nil {
+				return nil, err
+			}
+			return echoConn(nil), nil
+		}
+		return protocol
+	}
+}
+
+// TestICMPCheckerPrivileged verifies raw and ping sockets are chosen by the privileged mode.
+func TestICMPCheckerPrivileged(t *testing.T) {
+	t.Parallel()
+
+	eperm := &net.OpError{Op: "listen", Net: icmpv4Network, Err: syscall.EPERM}
+
+	tests := []struct {
+		name         string
+		privileged   ICMPPrivileged
+		rawErr       error
+		dgramErr     error
+		wantErr      string
+		wantDatagram bool
+	}{
+		{name: "auto uses raw sockets", privileged: ICMPPrivilegedAuto},
+		{name: "auto falls back to ping sockets", privileged: ICMPPrivilegedAuto, rawErr: eperm, wantDatagram: true},
+		{
+			name:         "auto without permissions",
+			privileged:   ICMPPrivilegedAuto,
+			rawErr:       eperm,
+			dgramErr:     eperm,
+			wantErr:      "ICMP needs the CAP_NET_RAW capability or a group ID within net.ipv4.ping_group_range: failed to listen for ICMP packets: listen ip4:icmp: operation not permitted",
+			wantDatagram: true,
+		},
+		{
+			name:       "raw sockets without permissions",
+			privileged: ICMPPrivilegedTrue,
+			rawErr:     eperm,
+			wantErr:    "raw ICMP sockets need the CAP_NET_RAW capability: failed to listen for ICMP packets: listen ip4:icmp: operation not permitted",
+		},
+		{
+			name:       "ping sockets without permissions",
+			privileged: ICMPPrivilegedFalse,
+			dgramErr:   eperm,
+			wantErr:    "ICMP ping sockets need a group ID within net.ipv4.ping_group_range: failed to listen for ICMP packets: listen ip4:icmp: operation not permitted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := &ICMPChecker{
+				name:        "Privileged",
+				address:     testutils.LocalhostIPv4,
+				privileged:  tt.privileged,
+				protocolFor: socketProtocols(tt.rawErr, tt.dgramErr),
+				readTimeout: time.Second,
+				listeners:   newICMPListeners(),
+			}
+
+			err := checker.Check(context.Background())
+			assert.Equal(t, tt.wantDatagram, checker.datagram.Load())
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.EqualError(t, err, tt.wantErr)
+			assert.True(t, IsPermanent(err))
+			assert.ErrorIs(t, err, syscall.EPERM)
+		})
+	}
+}
+
+// TestICMPDatagramValidateReply verifies ping socket replies are matched by sequence only.
+func TestICMPDatagramValidateReply(t *testing.T) {
+	t.Parallel()
+
+	reply, err := (&icmp.Message{
+		Type: ipv4.ICMPTypeEchoReply,
+		Body: &icmp.Echo{ID: 40000, Seq: 7, Data: []byte("HELLO-R-U-THERE")},
+	}).Marshal(nil)
+	require.NoError(t, err)
+
+	require.NoError(t, (&ICMPv4{icmpOptions: icmpOptions{datagram: true}}).ValidateReply(reply, 1234, 7))
+	assert.EqualError(t, (&ICMPv4{icmpOptions: icmpOptions{datagram: true}}).ValidateReply(reply, 1234, 8), "identifier or sequence mismatch")
+	assert.EqualError(t, (&ICMPv4{}).ValidateReply(reply, 1234, 7), "identifier or sequence mismatch")
+}
+
+// TestICMPCheckerPingSocket pings localhost over an unprivileged ping socket.
+func TestICMPCheckerPingSocket(t *testing.T) {
+	t.Parallel()
+
+	checker, err := newICMPChecker("PingSocket", testutils.LocalhostIPv4, WithICMPPrivileged(ICMPPrivilegedFalse), WithICMPTimeout(time.Second))
+	require.NoError(t, err)
+
+	err = checker.Check(context.Background())
+	if isPermissionDenied(err) {
+		t.Skip("skipping: ping sockets are not permitted by net.ipv4.ping_group_range")
+	}
+	require.NoError(t, err)
+}
@@ -0,0 +1,123 @@
+package checker
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// ICMPStats summarizes the replies to a burst of echo requests.
+type ICMPStats struct {
+	Sent     int
+	Received int
+	MinRTT   time.Duration
+	AvgRTT   time.Duration
+	MaxRTT   time.Duration
+	Jitter   time.Duration // Jitter is the mean difference between consecutive round-trip times.
+}
+
+// newICMPStats computes the statistics of the replies to the sequences in send order.
+func newICMPStats(sent []uint16, rtts map[uint16]time.Duration) ICMPStats {
+	stats := ICMPStats{Sent: len(sent), Received: len(rtts)}
+
+	var total, jitter time.Duration
+	var prev time.Duration
+	first := true
+	for _, seq := range sent {
+		rtt, ok := rtts[seq]
+		if !ok {
+			continue
+		}
+		total += rtt
+		if first {
+			stats.MinRTT, stats.MaxRTT = rtt, rtt
+			first = false
+		} else {
+			stats.MinRTT = min(stats.MinRTT, rtt)
+			stats.MaxRTT = max(stats.MaxRTT, rtt)
+			jitter += (rtt - prev).Abs()
+		}
+		prev = rtt
+	}
+
+	if stats.Received > 0 {
+		stats.AvgRTT = total / time.Duration(stats.Received)
+	}
+	if stats.Received > 1 {
+		stats.Jitter = jitter / time.Duration(stats.Received-1)
+	}
+
+	return stats
+}
+
+// Loss returns the percentage of echo requests without a reply.
+func (s ICMPStats) Loss() float64 {
+	if s.Sent == 0 {
+		return 0
+	}
+
+	return float64(s.Sent-s.Received) / float64(s.Sent) * 100
+}
+
+// String returns the statistics in a ping-like format.
+func (s ICMPStats) String() string {
+	if s.Received == 0 {
+		return fmt.Sprintf("%d/%d received", s.Received, s.Sent)
+	}
+
+	return fmt.Sprintf("%d/%d received, rtt min/avg/max/jitter %s/%s/%s/%s", s.Received, s.Sent,
+		roundRTT(s.MinRTT), roundRTT(s.AvgRTT), roundRTT(s.MaxRTT), roundRTT(s.Jitter))
+}
+
+// LogValue implements slog.LogValuer.
+func (s ICMPStats) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("sent", s.Sent),
+		slog.Int("received", s.Received),
+		slog.Float64("loss_percent", s.Loss()),
+		slog.Duration("rtt_min", s.MinRTT),
+		slog.Duration("rtt_avg", s.AvgRTT),
+		slog.Duration("rtt_max", s.MaxRTT),
+		slog.Duration("jitter", s.Jitter),
+	)
+}
+
+// roundRTT rounds a round-trip time for display.
+func roundRTT(d time.Duration) time.Duration { return d.Round(time.Microsecond) }
+
+// ICMPStatsError is returned when the replies to a burst of echo requests exceed the loss or RTT threshold.
+type ICMPStatsError struct {
+	Reason string
+	Stats  ICMPStats
+	Err    error // Err is the read error that ended the burst early, if any.
+}
+
+// Error returns the error message including the statistics.
+func (e *ICMPStatsError) Error() string {
+	return fmt.Sprintf("%s (%s)", e.Reason, e.Stats)
+}
+
+// Unwrap returns the read error that ended the burst early.
+func (e *ICMPStatsError) Unwrap() error { return e.Err }
+
+// StatsReporter is implemented by checkers that collect statistics during an attempt.
+type StatsReporter interface {
+	// LastStats returns the statistics of the most recent attempt, if any.
+	LastStats() (slog.Value, bool)
+}
+
+// LastStats returns the statistics of the most recent attempt of c or of the checker it wraps.
+func LastStats(c Checker) (slog.Value, bool) {
+	for c != nil {
+		if r, ok := c.(StatsReporter); ok {
+			return r.LastStats()
+		}
+		w, ok := c.(interface{ Unwrap() Checker })
+		if !ok {
+			break
+		}
+		c = w.Unwrap()
+	}
+
+	return slog.Value{}, false
+}
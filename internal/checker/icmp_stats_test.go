@@ -0,0 +1,96 @@
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/containeroo/never/internal/testutils"
+)
+
+// TestNewICMPStats verifies loss, round-trip times and jitter are computed in send order.
+func TestNewICMPStats(t *testing.T) {
+	t.Parallel()
+
+	stats := newICMPStats([]uint16{1, 2, 3, 4}, map[uint16]time.Duration{
+		1: 10 * time.Millisecond,
+		2: 30 * time.Millisecond,
+		4: 20 * time.Millisecond,
+	})
+
+	assert.Equal(t, ICMPStats{
+		Sent:     4,
+		Received: 3,
+		MinRTT:   10 * time.Millisecond,
+		AvgRTT:   20 * time.Millisecond,
+		MaxRTT:   30 * time.Millisecond,
+		Jitter:   15 * time.Millisecond,
+	}, stats)
+	assert.InDelta(t, 25.0, stats.Loss(), 0.001)
+	assert.Equal(t, "3/4 received, rtt min/avg/max/jitter 10ms/20ms/30ms/15ms", stats.String())
+
+	empty := newICMPStats([]uint16{1}, nil)
+	assert.InDelta(t, 100.0, empty.Loss(), 0.001)
+	assert.Equal(t, "0/1 received", empty.String())
+}
+
+// TestICMPCheckerBurst verifies a burst of echo requests is compared with the loss and RTT thresholds.
+func TestICMPCheckerBurst(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		drop    map[int]bool
+		maxLoss float64
+		maxRTT  time.Duration
+		wantErr string
+	}{
+		{name: "no loss"},
+		{name: "loss within threshold", drop: map[int]bool{2: true}, maxLoss: 25},
+		{name: "loss above threshold", drop: map[int]bool{0: true, 2: true}, maxLoss: 25, wantErr: "packet loss 50% exceeds max loss 25% (2/4 received"},
+		{name: "all lost", drop: map[int]bool{0: true, 1: true, 2: true, 3: true}, maxLoss: 100, wantErr: "no ICMP reply received (0/4 received)"},
+		{name: "rtt above threshold", maxRTT: time.Nanosecond, wantErr: "average rtt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := &ICMPChecker{
+				name:        "Burst",
+				address:     testutils.LocalhostIPv4,
+				protocolFor: fixedProtocol(echoProtocol(echoConn(tt.drop))),
+				readTimeout: 100 * time.Millisecond,
+				count:       4,
+				listeners:   newICMPListeners(),
+				maxLoss:     tt.maxLoss,
+				maxRTT:      tt.maxRTT,
+			}
+
+			err := checker.Check(context.Background())
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				var statsErr *ICMPStatsError
+				require.ErrorAs(t, err, &statsErr)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+
+			_, ok := LastStats(NewLatencyChecker(checker, time.Hour, 100, 1))
+			assert.True(t, ok)
+			assert.Equal(t, 4, checker.lastStats.Load().Sent)
+			assert.Equal(t, 4-len(tt.drop), checker.lastStats.Load().Received)
+		})
+	}
+}
+
+// TestLastStatsUnsupported verifies checkers without statistics report none.
+func TestLastStatsUnsupported(t *testing.T) {
+	t.Parallel()
+
+	_, ok := LastStats(stubChecker{})
+	assert.False(t, ok)
+}
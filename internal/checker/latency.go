@@ -0,0 +1,99 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// LatencyError is returned when a check succeeded but was slower than allowed.
+type LatencyError struct {
+	Latency    time.Duration // Latency is the measured latency or percentile.
+	MaxLatency time.Duration
+	Percentile int
+	Window     int
+}
+
+// Error returns the error message.
+func (e *LatencyError) Error() string {
+	if e.Window <= 1 {
+		return fmt.Sprintf("latency %s exceeds max latency %s", e.Latency, e.MaxLatency)
+	}
+
+	return fmt.Sprintf("p%d latency %s over the last %d attempts exceeds max latency %s", e.Percentile, e.Latency, e.Window, e.MaxLatency)
+}
+
+// LatencyChecker wraps a Checker and fails successful checks that are too slow.
+// With a window greater than one, the given percentile of the latencies of the
+// last window successful attempts must stay within maxLatency. Until the window
+// is full, every attempt is compared on its own.
+type LatencyChecker struct {
+	Checker
+	maxLatency time.Duration
+	percentile int
+	window     int
+
+	mu      sync.Mutex
+	samples []time.Duration
+}
+
+// NewLatencyChecker wraps c with a latency threshold.
+func NewLatencyChecker(c Checker, maxLatency time.Duration, percentile, window int) *LatencyChecker {
+	return &LatencyChecker{
+		Checker:    c,
+		maxLatency: maxLatency,
+		percentile: min(max(percentile, 1), 100),
+		window:     max(window, 1),
+	}
+}
+
+// Unwrap returns the wrapped checker.
+func (c *LatencyChecker) Unwrap() Checker { return c.Checker }
+
+// Check runs the wrapped check and compares its latency with the threshold.
+func (c *LatencyChecker) Check(ctx context.Context) error {
+	start := time.Now()
+	if err := c.Checker.Check(ctx); err != nil {
+		return err
+	}
+
+	return c.observe(time.Since(start))
+}
+
+// observe records a successful attempt and returns an error when the threshold is exceeded.
+func (c *LatencyChecker) observe(latency time.Duration) error {
+	if c.window > 1 {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.samples = append(c.samples, latency)
+		if len(c.samples) > c.window {
+			c.samples = c.samples[len(c.samples)-c.window:]
+		}
+		if len(c.samples) == c.window {
+			p := percentile(c.samples, c.percentile)
+			if p > c.maxLatency {
+				return &LatencyError{Latency: p, MaxLatency: c.maxLatency, Percentile: c.percentile, Window: c.window}
+			}
+			return nil
+		}
+	}
+
+	if latency > c.maxLatency {
+		return &LatencyError{Latency: latency, MaxLatency: c.maxLatency, Percentile: c.percentile, Window: 1}
+	}
+
+	return nil
+}
+
+// percentile returns the nearest-rank percentile p of samples.
+func percentile(samples []time.Duration, p int) time.Duration {
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
@@ -0,0 +1,77 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubChecker is a Checker returning a fixed error.
+type stubChecker struct{ err error }
+
+func (s stubChecker) Check(context.Context) error { return s.err }
+func (s stubChecker) Name() string                { return "stub" }
+func (s stubChecker) Type() string                { return "stub" }
+func (s stubChecker) Address() string             { return "stub" }
+
+// TestLatencyCheckerSingle verifies a single attempt is compared with the threshold.
+func TestLatencyCheckerSingle(t *testing.T) {
+	t.Parallel()
+
+	c := NewLatencyChecker(stubChecker{}, 100*time.Millisecond, 100, 1)
+
+	require.NoError(t, c.observe(50*time.Millisecond))
+
+	err := c.observe(150 * time.Millisecond)
+	var latencyErr *LatencyError
+	require.ErrorAs(t, err, &latencyErr)
+	assert.Equal(t, 150*time.Millisecond, latencyErr.Latency)
+	assert.EqualError(t, err, "latency 150ms exceeds max latency 100ms")
+}
+
+// TestLatencyCheckerPercentile verifies the percentile is calculated over the last window attempts.
+func TestLatencyCheckerPercentile(t *testing.T) {
+	t.Parallel()
+
+	c := NewLatencyChecker(stubChecker{}, 100*time.Millisecond, 50, 3)
+
+	// Until the window is full, every attempt is compared on its own.
+	require.NoError(t, c.observe(10*time.Millisecond))
+	err := c.observe(500 * time.Millisecond)
+	assert.EqualError(t, err, "latency 500ms exceeds max latency 100ms")
+
+	// p50 of 10ms, 500ms, 20ms is 20ms.
+	require.NoError(t, c.observe(20*time.Millisecond))
+
+	// The window drops 10ms: p50 of 500ms, 20ms, 300ms is 300ms.
+	err = c.observe(300 * time.Millisecond)
+	assert.EqualError(t, err, "p50 latency 300ms over the last 3 attempts exceeds max latency 100ms")
+}
+
+// TestLatencyCheckerFailure verifies errors of the wrapped checker are passed through unchanged.
+func TestLatencyCheckerFailure(t *testing.T) {
+	t.Parallel()
+
+	want := errors.New("boom")
+	c := NewLatencyChecker(stubChecker{err: want}, time.Hour, 100, 1)
+
+	assert.ErrorIs(t, c.Check(context.Background()), want)
+	assert.Equal(t, "stub", c.Name())
+}
+
+// TestPercentile verifies the nearest-rank percentile.
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+
+	samples := []time.Duration{5, 1, 4, 2, 3}
+
+	assert.Equal(t, time.Duration(1), percentile(samples, 1))
+	assert.Equal(t, time.Duration(3), percentile(samples, 50))
+	assert.Equal(t, time.Duration(5), percentile(samples, 90))
+	assert.Equal(t, time.Duration(5), percentile(samples, 100))
+	assert.Equal(t, []time.Duration{5, 1, 4, 2, 3}, samples)
+}
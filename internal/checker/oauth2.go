@@ -0,0 +1,162 @@
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// oauth2ExpiryDelta is how long before its expiry a cached token is refreshed.
+const oauth2ExpiryDelta = 10 * time.Second
+
+// maxTokenResponseSize limits the token endpoint response that is read.
+const maxTokenResponseSize = 1 << 20
+
+// OAuth2Config configures the OAuth2 client credentials grant (RFC 6749, section 4.4).
+type OAuth2Config struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// TokenError is returned when no access token could be fetched. The target itself was not checked.
+type TokenError struct {
+	TokenURL string
+	Err      error
+}
+
+// Error returns the error message naming the token endpoint.
+func (e *TokenError) Error() string {
+	return fmt.Sprintf("failed to fetch OAuth2 token from %s: %v", e.TokenURL, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *TokenError) Unwrap() error { return e.Err }
+
+// oauth2TokenSource fetches access tokens with the client credentials grant and caches them until shortly before expiry.
+type oauth2TokenSource struct {
+	config OAuth2Config
+	client *http.Client
+	now    func() time.Time
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time // expiry is zero for tokens without expires_in.
+}
+
+// tokenResponse is the successful token endpoint response (RFC 6749, section 5.1).
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// tokenErrorResponse is the error response of the token endpoint (RFC 6749, section 5.2).
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// newOAuth2TokenSource creates a token source that reaches the token endpoint with client.
+func newOAuth2TokenSource(config OAuth2Config, client *http.Client) *oauth2TokenSource {
+	return &oauth2TokenSource{config: config, client: client, now: time.Now}
+}
+
+// Token returns the cached access token, or fetches a new one if there is none or it is about to expire.
+func (s *oauth2TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || s.now().Add(oauth2ExpiryDelta).Before(s.expiry)) {
+		return s.token, nil
+	}
+
+	token, expiresIn, err := s.fetch(ctx)
+	if err != nil {
+		return "", &TokenError{TokenURL: s.config.TokenURL, Err: err}
+	}
+
+	s.token, s.expiry = token, time.Time{}
+	if expiresIn > 0 {
+		s.expiry = s.now().Add(expiresIn)
+	}
+
+	return s.token, nil
+}
+
+// Invalidate drops the cached token, for example after the target rejected it.
+func (s *oauth2TokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token, s.expiry = "", time.Time{}
+}
+
+// fetch requests a new access token and returns it with its lifetime.
+func (s *oauth2TokenSource) fetch(ctx context.Context) (string, time.Duration, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.config.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.config.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	// Client credentials are form-encoded before being used for basic authentication (RFC 6749, section 2.3.1).
+	req.SetBasicAuth(url.QueryEscape(s.config.ClientID), url.QueryEscape(s.config.ClientSecret))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close() // nolint:errcheck
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTokenResponseSize))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp tokenErrorResponse
+		if json.Unmarshal(body, &errResp) == nil && errResp.Error != "" {
+			if errResp.ErrorDescription != "" {
+				return "", 0, fmt.Errorf("status %d: %s: %s", resp.StatusCode, errResp.Error, errResp.ErrorDescription)
+			}
+			return "", 0, fmt.Errorf("status %d: %s", resp.StatusCode, errResp.Error)
+		}
+		return "", 0, fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	var tokenResp tokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", 0, fmt.Errorf("invalid response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return "", 0, errors.New("response contains no access_token")
+	}
+	if tokenResp.TokenType != "" && !strings.EqualFold(tokenResp.TokenType, "bearer") {
+		return "", 0, fmt.Errorf("unsupported token type %q", tokenResp.TokenType)
+	}
+
+	return tokenResp.AccessToken, time.Duration(tokenResp.ExpiresIn) * time.Second, nil
+}
+
+// WithHTTPOAuth2 sets the client credentials the HTTPChecker fetches a bearer token with before every attempt.
+func WithHTTPOAuth2(config OAuth2Config) Option {
+	return OptionFunc(func(c Checker) {
+		if httpChecker, ok := c.(*HTTPChecker); ok {
+			httpChecker.oauth2 = &config
+		}
+	})
+}
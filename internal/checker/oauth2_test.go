@@ -0,0 +1,169 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveTokens starts a token endpoint issuing numbered tokens for client "never" with secret "s3cr%t".
+// It returns the endpoint URL and the number of issued tokens.
+func serveTokens(t *testing.T, expiresIn int) (string, *atomic.Int32) {
+	t.Helper()
+
+	var issued atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "never" || secret != "s3cr%25t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"client authentication failed"}`))
+			return
+		}
+		if r.PostFormValue("grant_type") != "client_credentials" || r.PostFormValue("scope") != "health:read metrics" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_request"}`))
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, issued.Add(1), expiresIn)
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL, &issued
+}
+
+// TestHTTPCheckerOAuth2 verifies bearer tokens are fetched, cached, sent with every attempt and refreshed when rejected.
+func TestHTTPCheckerOAuth2(t *testing.T) {
+	t.Parallel()
+
+	tokenURL, issued := serveTokens(t, 3600)
+
+	var authorization atomic.Value
+	var reject atomic.Bool
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization.Store(r.Header.Get("Authorization"))
+		if reject.Load() {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	t.Cleanup(target.Close)
+
+	config := OAuth2Config{TokenURL: tokenURL, ClientID: "never", ClientSecret: "s3cr%t", Scopes: []string{"health:read", "metrics"}}
+	checker, err := newHTTPChecker("oauth2", target.URL, WithHTTPOAuth2(config))
+	require.NoError(t, err)
+
+	require.NoError(t, checker.Check(context.Background()))
+	require.NoError(t, checker.Check(context.Background()))
+	assert.Equal(t, "Bearer token-1", authorization.Load())
+	assert.Equal(t, int32(1), issued.Load(), "token must be cached")
+
+	reject.Store(true)
+	var statusErr *UnexpectedStatusCodeError
+	require.ErrorAs(t, checker.Check(context.Background()), &statusErr)
+
+	reject.Store(false)
+	require.NoError(t, checker.Check(context.Background()))
+	assert.Equal(t, "Bearer token-2", authorization.Load(), "rejected token must be replaced")
+}
+
+// TestHTTPCheckerOAuth2TokenError verifies token endpoint failures are reported as TokenError without checking the target.
+func TestHTTPCheckerOAuth2TokenError(t *testing.T) {
+	t.Parallel()
+
+	tokenURL, _ := serveTokens(t, 3600)
+
+	var requests atomic.Int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { requests.Add(1) }))
+	t.Cleanup(target.Close)
+
+	checker, err := newHTTPChecker("oauth2", target.URL, WithHTTPOAuth2(OAuth2Config{TokenURL: tokenURL, ClientID: "never", ClientSecret: "wrong"}))
+	require.NoError(t, err)
+
+	err = checker.Check(context.Background())
+	var tokenErr *TokenError
+	require.ErrorAs(t, err, &tokenErr)
+	assert.EqualError(t, err, "failed to fetch OAuth2 token from "+tokenURL+": status 401: invalid_client: client authentication failed")
+	assert.Zero(t, requests.Load())
+}
+
+// TestHTTPCheckerOAuth2Transport verifies the token endpoint is reached through the proxy of the target,
+// but without skipping TLS verification.
+func TestHTTPCheckerOAuth2Transport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("proxy", func(t *testing.T) {
+		t.Parallel()
+
+		var authorization atomic.Value
+		proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Host == "auth.never.invalid" {
+				_, _ = w.Write([]byte(`{"access_token":"proxied","token_type":"Bearer"}`))
+				return
+			}
+			authorization.Store(r.Header.Get("Authorization"))
+		}))
+		t.Cleanup(proxyServer.Close)
+		proxyURL, err := url.Parse(proxyServer.URL)
+		require.NoError(t, err)
+
+		config := OAuth2Config{TokenURL: "http://auth.never.invalid/token", ClientID: "never"}
+		checker, err := newHTTPChecker("oauth2", "http://api.never.invalid/healthz", WithProxy(proxyURL), WithHTTPOAuth2(config))
+		require.NoError(t, err)
+		require.NoError(t, checker.Check(context.Background()))
+		assert.Equal(t, "Bearer proxied", authorization.Load())
+	})
+
+	t.Run("skip TLS verify", func(t *testing.T) {
+		t.Parallel()
+
+		tokenServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		t.Cleanup(tokenServer.Close)
+		target := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		t.Cleanup(target.Close)
+
+		config := OAuth2Config{TokenURL: tokenServer.URL, ClientID: "never"}
+		checker, err := newHTTPChecker("oauth2", target.URL, WithHTTPSkipTLSVerify(true), WithHTTPOAuth2(config))
+		require.NoError(t, err)
+
+		err = checker.Check(context.Background())
+		var tokenErr *TokenError
+		require.ErrorAs(t, err, &tokenErr)
+		assert.True(t, isCertificateError(err))
+	})
+}
+
+// TestOAuth2TokenSourceRefresh verifies tokens are refreshed shortly before they expire.
+func TestOAuth2TokenSourceRefresh(t *testing.T) {
+	t.Parallel()
+
+	tokenURL, issued := serveTokens(t, 60)
+
+	now := time.Now()
+	source := newOAuth2TokenSource(OAuth2Config{TokenURL: tokenURL, ClientID: "never", ClientSecret: "s3cr%t", Scopes: []string{"health:read", "metrics"}}, http.DefaultClient)
+	source.now = func() time.Time { return now }
+
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	now = now.Add(45 * time.Second)
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	now = now.Add(10 * time.Second)
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, int32(2), issued.Load())
+}
@@ -0,0 +1,60 @@
+package checker
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+)
+
+// PermanentError marks a check failure that will not resolve itself by retrying,
+// such as rejected credentials or an untrusted certificate.
+type PermanentError struct {
+	Err error
+}
+
+// Error returns the error message.
+func (e *PermanentError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the underlying error.
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// IsPermanent reports whether err is or wraps a PermanentError.
+// Errors of single addresses within a MultiAddressError are not considered.
+func IsPermanent(err error) bool {
+	_, ok := asTargetError[*PermanentError](err)
+	return ok
+}
+
+// asTargetError finds the first error of type T in the tree of err like errors.As,
+// but does not descend into the errors of single addresses within a MultiAddressError.
+func asTargetError[T error](err error) (T, bool) {
+	switch e := err.(type) {
+	case T:
+		return e, true
+	case *MultiAddressError:
+	case interface{ Unwrap() error }:
+		return asTargetError[T](e.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			if target, ok := asTargetError[T](err); ok {
+				return target, true
+			}
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// isCertificateError reports whether err is a TLS certificate verification failure.
+func isCertificateError(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	var verification *tls.CertificateVerificationError
+
+	return errors.As(err, &unknownAuthority) ||
+		errors.As(err, &hostname) ||
+		errors.As(err, &invalid) ||
+		errors.As(err, &verification)
+}
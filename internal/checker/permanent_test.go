@@ -0,0 +1,92 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIsPermanent verifies wrapped permanent errors are detected.
+func TestIsPermanent(t *testing.T) {
+	t.Parallel()
+
+	base := errors.New("forbidden")
+	err := &PermanentError{Err: base}
+
+	assert.True(t, IsPermanent(err))
+	assert.True(t, IsPermanent(errors.Join(errors.New("other"), err)))
+	assert.False(t, IsPermanent(base))
+	assert.ErrorIs(t, err, base)
+	assert.EqualError(t, err, "forbidden")
+}
+
+// TestHTTPCheckerPermanentErrors verifies which HTTP failures are classified as permanent.
+func TestHTTPCheckerPermanentErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fail fast status code", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		c, err := newHTTPChecker("auth", server.URL, WithFailFastStatusCodes([]int{401, 403}))
+		require.NoError(t, err)
+
+		err = c.Check(context.Background())
+		assert.True(t, IsPermanent(err))
+
+		var statusErr *UnexpectedStatusCodeError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+	})
+
+	t.Run("other status code", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		c, err := newHTTPChecker("gateway", server.URL, WithFailFastStatusCodes([]int{401, 403}))
+		require.NoError(t, err)
+
+		err = c.Check(context.Background())
+		require.Error(t, err)
+		assert.False(t, IsPermanent(err))
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		server.Config.ErrorLog = log.New(io.Discard, "", 0) // The rejected handshake is expected.
+		server.StartTLS()
+		defer server.Close()
+
+		// The certificate may not have been issued yet, so it is retried unless configured otherwise.
+		c, err := newHTTPChecker("tls", server.URL)
+		require.NoError(t, err)
+		err = c.Check(context.Background())
+		require.Error(t, err)
+		assert.False(t, IsPermanent(err))
+
+		c, err = newHTTPChecker("tls", server.URL, WithHTTPFailFastTLS(true))
+		require.NoError(t, err)
+		err = c.Check(context.Background())
+		require.Error(t, err)
+		assert.True(t, IsPermanent(err))
+	})
+}
@@ -0,0 +1,78 @@
+package checker
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/net/proxy"
+)
+
+var (
+	// HTTPProxySchemes are the proxy URL schemes supported by HTTP checkers.
+	// With socks5 and socks5h alike, the proxy resolves the target hostname.
+	HTTPProxySchemes = []string{"http", "https", "socks5", "socks5h"}
+	// TCPProxySchemes are the proxy URL schemes supported by TCP checkers.
+	// socks5 behaves like socks5h, the proxy resolves the target hostname.
+	TCPProxySchemes = []string{"socks5", "socks5h"}
+)
+
+// checkProxyScheme returns an error if the scheme of proxyURL is not one of schemes.
+func checkProxyScheme(proxyURL *url.URL, schemes []string) error {
+	if !slices.Contains(schemes, proxyURL.Scheme) {
+		return fmt.Errorf("unsupported proxy scheme %q: must be one of %v", proxyURL.Scheme, schemes)
+	}
+
+	return nil
+}
+
+// proxyFromEnvironment returns the proxy for a request from HTTP_PROXY, HTTPS_PROXY and NO_PROXY.
+// Unlike http.ProxyFromEnvironment, the environment is read when the checker is created, not once per process.
+func proxyFromEnvironment() func(*http.Request) (*url.URL, error) {
+	proxyFunc := httpproxy.FromEnvironment().ProxyFunc()
+
+	return func(req *http.Request) (*url.URL, error) { return proxyFunc(req.URL) }
+}
+
+// newTCPProxyDialer returns a dialer that connects through the SOCKS5 proxy at proxyURL using forward.
+func newTCPProxyDialer(proxyURL *url.URL, forward proxy.Dialer) (proxy.ContextDialer, error) {
+	if err := checkProxyScheme(proxyURL, TCPProxySchemes); err != nil {
+		return nil, err
+	}
+
+	dialer, err := proxy.FromURL(proxyURL, forward)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create proxy dialer: %w", err)
+	}
+
+	contextDialer, ok := dialer.(proxy.ContextDialer)
+	if !ok {
+		return nil, fmt.Errorf("proxy scheme %q does not support dialing with a context", proxyURL.Scheme)
+	}
+
+	return contextDialer, nil
+}
+
+// WithProxy sets the proxy HTTP and TCP checkers connect through instead of the proxy from the environment.
+// Credentials are taken from the user information of the URL.
+func WithProxy(proxyURL *url.URL) Option {
+	return OptionFunc(func(c Checker) {
+		switch chk := c.(type) {
+		case *HTTPChecker:
+			chk.proxy = proxyURL
+		case *TCPChecker:
+			chk.proxy = proxyURL
+		}
+	})
+}
+
+// WithHTTPNoProxy sets whether the HTTPChecker ignores the proxy from the environment.
+func WithHTTPNoProxy(noProxy bool) Option {
+	return OptionFunc(func(c Checker) {
+		if httpChecker, ok := c.(*HTTPChecker); ok {
+			httpChecker.noProxy = noProxy
+		}
+	})
+}
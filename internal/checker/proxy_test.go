@@ -0,0 +1,233 @@
+package checker
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/containeroo/never/internal/testutils"
+)
+
+// serveSOCKS5 starts a SOCKS5 proxy without authentication that connects to the requested target,
+// and returns its URL and the last requested target.
+func serveSOCKS5(t *testing.T) (*url.URL, *atomic.Value) {
+	t.Helper()
+
+	listener := testutils.ListenLocalTCP(t)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	var requested atomic.Value
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close() // nolint:errcheck
+				target, err := socks5Handshake(conn)
+				if err != nil {
+					return
+				}
+				requested.Store(target)
+
+				upstream, err := net.Dial("tcp", target)
+				if err != nil {
+					_, _ = conn.Write([]byte{5, 5, 0, 1, 0, 0, 0, 0, 0, 0})
+					return
+				}
+				defer upstream.Close() // nolint:errcheck
+				_, _ = conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+
+				go func() { _, _ = io.Copy(upstream, conn) }()
+				_, _ = io.Copy(conn, upstream)
+			}()
+		}
+	}()
+
+	return &url.URL{Scheme: "socks5", Host: listener.Addr().String()}, &requested
+}
+
+// socks5Handshake accepts the client greeting and returns the host:port of the CONNECT request (RFC 1928).
+func socks5Handshake(conn net.Conn) (string, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return "", err
+	}
+	if _, err := io.ReadFull(conn, make([]byte, header[1])); err != nil {
+		return "", err
+	}
+	if _, err := conn.Write([]byte{5, 0}); err != nil {
+		return "", err
+	}
+
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(conn, request); err != nil {
+		return "", err
+	}
+
+	var host string
+	switch request[3] {
+	case 1, 4:
+		ip := make(net.IP, 4)
+		if request[3] == 4 {
+			ip = make(net.IP, 16)
+		}
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return "", err
+		}
+		host = ip.String()
+	case 3:
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return "", err
+		}
+		name := make([]byte, length[0])
+		if _, err := io.ReadFull(conn, name); err != nil {
+			return "", err
+		}
+		host = string(name)
+	}
+
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), nil
+}
+
+// TestHTTPCheckerProxy verifies HTTP requests are sent through the configured HTTP or SOCKS5 proxy.
+func TestHTTPCheckerProxy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("HTTP proxy", func(t *testing.T) {
+		t.Parallel()
+
+		var requested atomic.Value
+		proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested.Store(r.URL.String())
+		}))
+		t.Cleanup(proxyServer.Close)
+		proxyURL, err := url.Parse(proxyServer.URL)
+		require.NoError(t, err)
+
+		checker, err := newHTTPChecker("proxy", "http://never.invalid/healthz", WithProxy(proxyURL))
+		require.NoError(t, err)
+		require.NoError(t, checker.Check(context.Background()))
+		assert.Equal(t, "http://never.invalid/healthz", requested.Load())
+	})
+
+	t.Run("SOCKS5 proxy", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		t.Cleanup(server.Close)
+		proxyURL, requested := serveSOCKS5(t)
+
+		checker, err := newHTTPChecker("proxy", server.URL, WithProxy(proxyURL))
+		require.NoError(t, err)
+		require.NoError(t, checker.Check(context.Background()))
+		assert.Equal(t, server.Listener.Addr().String(), requested.Load())
+	})
+
+	t.Run("no proxy", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := newHTTPChecker("proxy", "http://example.com", WithHTTPNoProxy(true))
+		require.NoError(t, err)
+		assert.Nil(t, checker.client.Transport.(*http.Transport).Proxy)
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newHTTPChecker("proxy", "http://example.com", WithProxy(&url.URL{Scheme: "ftp", Host: "proxy:21"}))
+		assert.EqualError(t, err, `unsupported proxy scheme "ftp": must be one of [http https socks5 socks5h]`)
+	})
+}
+
+// TestHTTPCheckerEnvironmentProxy verifies the proxy from the environment is kept unless the hostname must be resolved locally.
+func TestHTTPCheckerEnvironmentProxy(t *testing.T) {
+	var requested atomic.Value
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested.Store(r.URL.String())
+	}))
+	t.Cleanup(proxyServer.Close)
+	t.Setenv("HTTP_PROXY", proxyServer.URL)
+	t.Setenv("NO_PROXY", "")
+
+	t.Run("DNS timeout", func(t *testing.T) {
+		checker, err := newHTTPChecker("proxy", "http://never.invalid/healthz", WithDNSResolver("", time.Second))
+		require.NoError(t, err)
+		require.NoError(t, checker.Check(context.Background()))
+		assert.Equal(t, "http://never.invalid/healthz", requested.Load())
+	})
+
+	t.Run("DNS server", func(t *testing.T) {
+		checker, err := newHTTPChecker("proxy", "http://never.invalid/healthz", WithDNSResolver("192.0.2.53", time.Second))
+		require.NoError(t, err)
+		assert.Nil(t, checker.client.Transport.(*http.Transport).Proxy)
+	})
+}
+
+// TestTCPCheckerProxy verifies TCP connections are opened through the configured SOCKS5 proxy.
+func TestTCPCheckerProxy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SOCKS5 proxy", func(t *testing.T) {
+		t.Parallel()
+
+		listener := testutils.ListenLocalTCP(t)
+		t.Cleanup(func() { _ = listener.Close() })
+		proxyURL, requested := serveSOCKS5(t)
+
+		checker, err := newTCPChecker("proxy", listener.Addr().String(), WithProxy(proxyURL), WithTCPTimeout(time.Second))
+		require.NoError(t, err)
+		require.NoError(t, checker.Check(context.Background()))
+		assert.Equal(t, listener.Addr().String(), requested.Load())
+	})
+
+	t.Run("hostname resolved by the proxy", func(t *testing.T) {
+		t.Parallel()
+
+		listener := testutils.ListenLocalTCP(t)
+		t.Cleanup(func() { _ = listener.Close() })
+		proxyURL, requested := serveSOCKS5(t)
+		_, port, err := net.SplitHostPort(listener.Addr().String())
+		require.NoError(t, err)
+
+		checker, err := newTCPChecker("proxy", net.JoinHostPort("localhost", port), WithProxy(proxyURL), WithTCPTimeout(time.Second))
+		require.NoError(t, err)
+		require.NoError(t, checker.Check(context.Background()))
+		assert.Equal(t, net.JoinHostPort("localhost", port), requested.Load(), "socks5 must behave like socks5h")
+	})
+
+	t.Run("target unreachable", func(t *testing.T) {
+		t.Parallel()
+
+		proxyURL, _ := serveSOCKS5(t)
+
+		checker, err := newTCPChecker("proxy", testutils.LocalTCPAddr(t), WithProxy(proxyURL), WithTCPTimeout(time.Second))
+		require.NoError(t, err)
+		assert.ErrorContains(t, checker.Check(context.Background()), "connection refused")
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newTCPChecker("proxy", testutils.LocalhostAddr("5432"), WithProxy(&url.URL{Scheme: "http", Host: "proxy:3128"}))
+		assert.EqualError(t, err, `unsupported proxy scheme "http": must be one of [socks5 socks5h]`)
+	})
+}
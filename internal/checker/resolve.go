@@ -0,0 +1,169 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// ResolveMode controls which resolved addresses of a hostname are checked.
+type ResolveMode string
+
+const (
+	// ResolveFirst checks the target the way the operating system connects to it.
+	ResolveFirst ResolveMode = "first"
+	// ResolveAny checks every resolved address and needs a minimum number of them to pass.
+	ResolveAny ResolveMode = "any"
+	// ResolveAll checks every resolved address and needs all of them to pass.
+	ResolveAll ResolveMode = "all"
+)
+
+// String returns the user-facing mode value.
+func (m ResolveMode) String() string { return string(m) }
+
+// AddressResult is the outcome of checking a single resolved address.
+type AddressResult struct {
+	IP  net.IP
+	Err error
+}
+
+// MultiAddressError is returned when too few resolved addresses of a hostname passed the check.
+// It unwraps to the errors of the failed addresses so they can be classified, but IsPermanent and
+// RetryAfter ignore them: a single address must not decide how the whole target is retried.
+type MultiAddressError struct {
+	Host     string
+	Results  []AddressResult
+	Ready    int
+	Required int
+}
+
+// Error returns the error message including the result of every address.
+func (e *MultiAddressError) Error() string {
+	results := make([]string, 0, len(e.Results))
+	for _, r := range e.Results {
+		if r.Err == nil {
+			results = append(results, r.IP.String()+": ok")
+			continue
+		}
+		results = append(results, fmt.Sprintf("%s: %v", r.IP, r.Err))
+	}
+
+	return fmt.Sprintf("%d of %d addresses of %s ready, %d required: %s",
+		e.Ready, len(e.Results), e.Host, e.Required, strings.Join(results, "; "))
+}
+
+// Unwrap returns the errors of the failed addresses.
+func (e *MultiAddressError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Results))
+	for _, r := range e.Results {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+		}
+	}
+
+	return errs
+}
+
+// multiAddress checks every resolved address of a hostname individually.
+type multiAddress struct {
+	mode     ResolveMode
+	minReady int
+	network  string        // network restricts lookups to "ip4" or "ip6". Defaults to "ip".
+	resolver *net.Resolver // resolver defaults to the system resolver when nil.
+}
+
+// enabled reports whether resolved addresses are checked individually.
+func (m multiAddress) enabled() bool {
+	return m.mode == ResolveAny || m.mode == ResolveAll
+}
+
+// check resolves host and runs checkIP concurrently for every address.
+func (m multiAddress) check(ctx context.Context, host string, checkIP func(context.Context, net.IP) error) error {
+	ips, err := m.lookup(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	return m.checkIPs(ctx, host, ips, checkIP)
+}
+
+// checkIPs runs checkIP for every address and compares the number of passed addresses with the mode.
+func (m multiAddress) checkIPs(ctx context.Context, host string, ips []net.IP, checkIP func(context.Context, net.IP) error) error {
+	results := make([]AddressResult, len(ips))
+	var wg sync.WaitGroup
+	for i, ip := range ips {
+		results[i].IP = ip
+		wg.Go(func() { results[i].Err = checkIP(ctx, ip) })
+	}
+	wg.Wait()
+
+	ready := 0
+	for _, r := range results {
+		if r.Err == nil {
+			ready++
+		}
+	}
+
+	required := len(results)
+	if m.mode == ResolveAny {
+		required = min(max(m.minReady, 1), len(results))
+	}
+	if ready >= required {
+		return nil
+	}
+
+	multiErr := &MultiAddressError{Host: host, Results: results, Ready: ready, Required: required}
+
+	// Addresses that failed for a reason retrying cannot fix will not pass later attempts either.
+	recoverable := ready
+	for _, r := range results {
+		if r.Err != nil && !IsPermanent(r.Err) {
+			recoverable++
+		}
+	}
+	if recoverable < required {
+		return &PermanentError{Err: multiErr}
+	}
+
+	return multiErr
+}
+
+// lookup returns the addresses of host. IP literals are returned as is.
+func (m multiAddress) lookup(ctx context.Context, host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+
+	network := m.network
+	if network == "" {
+		network = "ip"
+	}
+
+	ips, err := m.resolver.LookupIP(ctx, network, host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	if len(ips) == 0 {
+		return nil, errors.New("no addresses resolved for " + host)
+	}
+
+	return ips, nil
+}
+
+// WithResolveMode sets which resolved addresses are checked.
+// minReady is the number of addresses that must pass with ResolveAny.
+func WithResolveMode(mode ResolveMode, minReady int) Option {
+	return OptionFunc(func(c Checker) {
+		switch chk := c.(type) {
+		case *HTTPChecker:
+			chk.multiAddress.mode, chk.multiAddress.minReady = mode, minReady
+		case *TCPChecker:
+			chk.multiAddress.mode, chk.multiAddress.minReady = mode, minReady
+		case *ICMPChecker:
+			chk.multiAddress.mode, chk.multiAddress.minReady = mode, minReady
+		}
+	})
+}
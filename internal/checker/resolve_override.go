@@ -0,0 +1,110 @@
+package checker
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ResolveOverride connects to IP instead of the resolved addresses of Host on Port, like curl --resolve.
+type ResolveOverride struct {
+	Host string
+	Port string
+	IP   net.IP
+}
+
+// String returns the override in host:port:ip format.
+func (o ResolveOverride) String() string {
+	ip := o.IP.String()
+	if o.IP.To4() == nil {
+		ip = "[" + ip + "]"
+	}
+
+	return o.Host + ":" + o.Port + ":" + ip
+}
+
+// ParseResolveOverride parses a host:port:ip value. IPv6 addresses may be enclosed in brackets.
+func ParseResolveOverride(s string) (ResolveOverride, error) {
+	host, rest, _ := strings.Cut(s, ":")
+	port, addr, ok := strings.Cut(rest, ":")
+	if !ok || host == "" {
+		return ResolveOverride{}, fmt.Errorf("invalid resolve override %q: expected host:port:ip", s)
+	}
+
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return ResolveOverride{}, fmt.Errorf("invalid port %q in resolve override %q", port, s)
+	}
+
+	ip := net.ParseIP(strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]"))
+	if ip == nil {
+		return ResolveOverride{}, fmt.Errorf("invalid IP %q in resolve override %q", addr, s)
+	}
+
+	return ResolveOverride{Host: host, Port: port, IP: ip}, nil
+}
+
+// resolveOverrides replaces the host of dialed addresses with a fixed IP.
+type resolveOverrides []ResolveOverride
+
+// Matches reports whether the override applies to address in host:port format.
+func (o ResolveOverride) Matches(address string) bool {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(o.Host, host) && o.Port == port
+}
+
+// address returns the address to connect to instead of address, or address if no override matches.
+func (o resolveOverrides) address(address string) string {
+	for _, override := range o {
+		if override.Matches(address) {
+			return net.JoinHostPort(override.IP.String(), override.Port)
+		}
+	}
+
+	return address
+}
+
+// bypassProxy returns a proxy function that connects directly to overridden hosts, which the proxy would
+// resolve itself, and uses proxy for all other hosts.
+func (o resolveOverrides) bypassProxy(proxy func(*http.Request) (*url.URL, error)) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		address := CanonicalAddress(req.URL)
+		if slices.ContainsFunc(o, func(override ResolveOverride) bool { return override.Matches(address) }) {
+			return nil, nil
+		}
+		return proxy(req)
+	}
+}
+
+// CanonicalAddress returns the host:port of u, with the default port of its scheme if u has none.
+func CanonicalAddress(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// WithResolveOverrides sets fixed IPs to connect to instead of resolving the hostname.
+// HTTP requests keep the hostname for the Host header and TLS server name.
+func WithResolveOverrides(overrides ...ResolveOverride) Option {
+	return OptionFunc(func(c Checker) {
+		switch chk := c.(type) {
+		case *HTTPChecker:
+			chk.resolveOverrides = overrides
+		case *TCPChecker:
+			chk.resolveOverrides = overrides
+		}
+	})
+}
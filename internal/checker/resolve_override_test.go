@@ -0,0 +1,119 @@
+package checker
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/containeroo/never/internal/testutils"
+)
+
+// TestParseResolveOverride verifies host:port:ip values are parsed and invalid values are rejected.
+func TestParseResolveOverride(t *testing.T) {
+	t.Parallel()
+
+	override, err := ParseResolveOverride("api.example.com:443:10.0.0.7")
+	require.NoError(t, err)
+	assert.Equal(t, ResolveOverride{Host: "api.example.com", Port: "443", IP: net.ParseIP("10.0.0.7")}, override)
+	assert.Equal(t, "api.example.com:443:10.0.0.7", override.String())
+
+	override, err = ParseResolveOverride("api.example.com:443:[2001:db8::7]")
+	require.NoError(t, err)
+	assert.Equal(t, net.ParseIP("2001:db8::7"), override.IP)
+	assert.Equal(t, "api.example.com:443:[2001:db8::7]", override.String())
+
+	for value, wantErr := range map[string]string{
+		"api.example.com":                 `invalid resolve override "api.example.com": expected host:port:ip`,
+		":443:10.0.0.7":                   `invalid resolve override ":443:10.0.0.7": expected host:port:ip`,
+		"api.example.com:https:10.0.0.7":  `invalid port "https" in resolve override "api.example.com:https:10.0.0.7"`,
+		"api.example.com:70000:10.0.0.7":  `invalid port "70000" in resolve override "api.example.com:70000:10.0.0.7"`,
+		"api.example.com:443:backend.lan": `invalid IP "backend.lan" in resolve override "api.example.com:443:backend.lan"`,
+	} {
+		_, err := ParseResolveOverride(value)
+		assert.EqualError(t, err, wantErr)
+	}
+}
+
+// TestResolveOverridesAddress verifies only the matching host and port are replaced.
+func TestResolveOverridesAddress(t *testing.T) {
+	t.Parallel()
+
+	overrides := resolveOverrides{{Host: "api.example.com", Port: "443", IP: net.ParseIP("2001:db8::7")}}
+
+	assert.Equal(t, "[2001:db8::7]:443", overrides.address("API.example.com:443"))
+	assert.Equal(t, "api.example.com:80", overrides.address("api.example.com:80"))
+	assert.Equal(t, "www.example.com:443", overrides.address("www.example.com:443"))
+	assert.Equal(t, "api.example.com:443", resolveOverrides(nil).address("api.example.com:443"))
+}
+
+// TestResolveOverridesBypassProxy verifies only overridden hosts are connected to without the proxy.
+func TestResolveOverridesBypassProxy(t *testing.T) {
+	t.Parallel()
+
+	proxyURL := &url.URL{Scheme: "http", Host: "proxy.example.com:3128"}
+	overrides := resolveOverrides{{Host: "api.example.com", Port: "443", IP: net.ParseIP("10.0.0.7")}}
+	proxy := overrides.bypassProxy(http.ProxyURL(proxyURL))
+
+	tests := []struct {
+		url  string
+		want *url.URL
+	}{
+		{url: "https://api.example.com/healthz", want: nil},
+		{url: "https://api.example.com:8443/healthz", want: proxyURL},
+		{url: "https://auth.example.com/token", want: proxyURL},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+		require.NoError(t, err)
+
+		got, err := proxy(req)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt.url)
+	}
+}
+
+// TestHTTPCheckerResolveOverride verifies requests connect to the override IP and keep the hostname for Host and SNI.
+func TestHTTPCheckerResolveOverride(t *testing.T) {
+	t.Parallel()
+
+	var host, serverName string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, serverName = r.Host, r.TLS.ServerName
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	override := ResolveOverride{Host: "api.example.com", Port: serverURL.Port(), IP: net.ParseIP(serverURL.Hostname())}
+	address := "https://api.example.com:" + serverURL.Port() + "/"
+
+	checker, err := newHTTPChecker("override", address, WithHTTPSkipTLSVerify(true), WithResolveOverrides(override))
+	require.NoError(t, err)
+
+	require.NoError(t, checker.Check(context.Background()))
+	assert.Equal(t, "api.example.com:"+serverURL.Port(), host)
+	assert.Equal(t, "api.example.com", serverName)
+}
+
+// TestTCPCheckerResolveOverride verifies connections go to the override IP instead of the resolved hostname.
+func TestTCPCheckerResolveOverride(t *testing.T) {
+	t.Parallel()
+
+	listener := testutils.ListenLocalTCP(t)
+	defer listener.Close() // nolint:errcheck
+
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	override := ResolveOverride{Host: "backend.invalid", Port: port, IP: net.ParseIP(testutils.LocalhostIPv4)}
+
+	checker, err := newTCPChecker("override", net.JoinHostPort("backend.invalid", port), WithResolveOverrides(override))
+	require.NoError(t, err)
+	require.NoError(t, checker.Check(context.Background()))
+}
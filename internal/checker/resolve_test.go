@@ -0,0 +1,162 @@
+package checker
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMultiAddressCheckIPs verifies the number of passed addresses is compared with the resolve mode.
+func TestMultiAddressCheckIPs(t *testing.T) {
+	t.Parallel()
+
+	ips := []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2"), net.ParseIP("10.0.0.3")}
+	refused := errors.New("connection refused")
+	checkIP := func(_ context.Context, ip net.IP) error {
+		if ip.Equal(ips[1]) {
+			return refused
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name    string
+		m       multiAddress
+		wantErr string
+	}{
+		{name: "any", m: multiAddress{mode: ResolveAny, minReady: 1}},
+		{name: "any with minimum", m: multiAddress{mode: ResolveAny, minReady: 2}},
+		{
+			name:    "any with minimum not reached",
+			m:       multiAddress{mode: ResolveAny, minReady: 3},
+			wantErr: "2 of 3 addresses of db.example.com ready, 3 required: 10.0.0.1: ok; 10.0.0.2: connection refused; 10.0.0.3: ok",
+		},
+		{
+			name:    "all",
+			m:       multiAddress{mode: ResolveAll},
+			wantErr: "2 of 3 addresses of db.example.com ready, 3 required: 10.0.0.1: ok; 10.0.0.2: connection refused; 10.0.0.3: ok",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.m.checkIPs(context.Background(), "db.example.com", ips, checkIP)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var multiErr *MultiAddressError
+			require.ErrorAs(t, err, &multiErr)
+			assert.Equal(t, 2, multiErr.Ready)
+			assert.EqualError(t, err, tt.wantErr)
+			assert.ErrorIs(t, err, refused)
+		})
+	}
+}
+
+// TestMultiAddressCheckIPsPermanent verifies a failure is only permanent when too few addresses can still pass.
+func TestMultiAddressCheckIPsPermanent(t *testing.T) {
+	t.Parallel()
+
+	ips := []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2")}
+	certErr := &PermanentError{Err: x509.UnknownAuthorityError{}}
+	refused := &RetryAfterError{Delay: time.Minute, Err: syscall.ECONNREFUSED}
+
+	tests := []struct {
+		name          string
+		mode          ResolveMode
+		errs          []error
+		wantPermanent bool
+	}{
+		{name: "any with one certificate failure and one refused", mode: ResolveAny, errs: []error{certErr, refused}},
+		{name: "any with every address refused", mode: ResolveAny, errs: []error{refused, refused}},
+		{name: "any with every address failing its certificate", mode: ResolveAny, errs: []error{certErr, certErr}, wantPermanent: true},
+		{name: "all with one certificate failure", mode: ResolveAll, errs: []error{certErr, nil}, wantPermanent: true},
+		{name: "all with one refused", mode: ResolveAll, errs: []error{refused, nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checkIP := func(_ context.Context, ip net.IP) error {
+				return tt.errs[slices.IndexFunc(ips, ip.Equal)]
+			}
+
+			err := multiAddress{mode: tt.mode, minReady: 1}.checkIPs(context.Background(), "db.example.com", ips, checkIP)
+			var multiErr *MultiAddressError
+			require.ErrorAs(t, err, &multiErr)
+			assert.Equal(t, tt.wantPermanent, IsPermanent(err))
+
+			_, ok := RetryAfter(err)
+			assert.False(t, ok, "Retry-After of a single address must not apply to the target")
+		})
+	}
+}
+
+// TestMultiAddressLookupIPLiteral verifies IP literals are checked without a DNS lookup.
+func TestMultiAddressLookupIPLiteral(t *testing.T) {
+	t.Parallel()
+
+	ips, err := multiAddress{mode: ResolveAll}.lookup(context.Background(), "::1")
+	require.NoError(t, err)
+	assert.Equal(t, []net.IP{net.ParseIP("::1")}, ips)
+}
+
+// TestTCPCheckerResolveAll verifies the TCP checker dials every resolved address.
+func TestTCPCheckerResolveAll(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close() // nolint:errcheck
+
+	_, port, err := net.SplitHostPort(ln.Addr().String())
+	require.NoError(t, err)
+
+	chk, err := NewChecker(TCP, "db", net.JoinHostPort("localhost", port), WithResolveMode(ResolveAll, 1))
+	require.NoError(t, err)
+	require.NoError(t, chk.Check(context.Background()))
+
+	require.NoError(t, ln.Close())
+	err = chk.Check(context.Background())
+	var multiErr *MultiAddressError
+	require.ErrorAs(t, err, &multiErr)
+	assert.Equal(t, 0, multiErr.Ready)
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+}
+
+// TestHTTPCheckerResolveAll verifies HTTP requests keep the hostname while connecting to each address.
+func TestHTTPCheckerResolveAll(t *testing.T) {
+	t.Parallel()
+
+	var gotHost string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost = r.Host
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	u.Host = net.JoinHostPort("localhost", u.Port())
+
+	chk, err := NewChecker(HTTP, "web", u.String(), WithResolveMode(ResolveAll, 1))
+	require.NoError(t, err)
+	require.NoError(t, chk.Check(context.Background()))
+	assert.Equal(t, u.Host, gotHost)
+}
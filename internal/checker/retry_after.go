@@ -0,0 +1,73 @@
+package checker
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRetryAfter caps the delay a target can request, so a huge Retry-After does not stall the check.
+const maxRetryAfter = time.Hour
+
+// RetryAfterError wraps a failed check with the delay the target asked to wait before retrying.
+type RetryAfterError struct {
+	Delay time.Duration
+	Err   error
+}
+
+// Error returns the error message.
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("%v (retry after %s)", e.Err, e.Delay)
+}
+
+// Unwrap returns the underlying error.
+func (e *RetryAfterError) Unwrap() error { return e.Err }
+
+// RetryAfter returns the server-suggested retry delay carried by err, if any.
+// Delays of single addresses within a MultiAddressError are not considered.
+func RetryAfter(err error) (time.Duration, bool) {
+	retryErr, ok := asTargetError[*RetryAfterError](err)
+	if !ok {
+		return 0, false
+	}
+
+	return retryErr.Delay, true
+}
+
+// honoursRetryAfter reports whether Retry-After is respected for a response status code.
+func honoursRetryAfter(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// parseRetryAfter parses a Retry-After header in delay-seconds or HTTP-date form.
+// It reports false for missing, invalid, zero or past values and caps the delay at maxRetryAfter.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds <= 0 {
+			return 0, false
+		}
+		// Compare before multiplying, large values would overflow into a negative duration.
+		if seconds > int64(maxRetryAfter/time.Second) {
+			return maxRetryAfter, true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	delay := date.Sub(now)
+	if delay <= 0 {
+		return 0, false
+	}
+
+	return min(delay.Round(time.Second), maxRetryAfter), true
+}
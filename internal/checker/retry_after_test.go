@@ -0,0 +1,98 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseRetryAfter verifies delay-seconds and HTTP-date values are parsed.
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "seconds", value: "120", want: 2 * time.Minute, wantOK: true},
+		{name: "http date", value: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second, wantOK: true},
+		{name: "empty", value: "", wantOK: false},
+		{name: "zero", value: "0", wantOK: false},
+		{name: "negative", value: "-5", wantOK: false},
+		{name: "past date", value: now.Add(-time.Minute).Format(http.TimeFormat), wantOK: false},
+		{name: "invalid", value: "soon", wantOK: false},
+		{name: "huge seconds", value: "864000", want: maxRetryAfter, wantOK: true},
+		{name: "overflowing seconds", value: "10000000000", want: maxRetryAfter, wantOK: true},
+		{name: "seconds out of range", value: "99999999999999999999", wantOK: false},
+		{name: "far future date", value: now.Add(30 * 24 * time.Hour).Format(http.TimeFormat), want: maxRetryAfter, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestHTTPCheckerRetryAfter verifies Retry-After is only carried for 429 and 503 responses.
+func TestHTTPCheckerRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		status    int
+		wantDelay time.Duration
+		wantOK    bool
+	}{
+		{name: "too many requests", status: http.StatusTooManyRequests, wantDelay: 5 * time.Second, wantOK: true},
+		{name: "service unavailable", status: http.StatusServiceUnavailable, wantDelay: 5 * time.Second, wantOK: true},
+		{name: "internal server error", status: http.StatusInternalServerError, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "5")
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			c, err := newHTTPChecker("retry", server.URL)
+			require.NoError(t, err)
+
+			err = c.Check(context.Background())
+			require.Error(t, err)
+
+			var statusErr *UnexpectedStatusCodeError
+			require.ErrorAs(t, err, &statusErr)
+			assert.Equal(t, tt.status, statusErr.StatusCode)
+
+			delay, ok := RetryAfter(err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantDelay, delay)
+		})
+	}
+
+	t.Run("message", func(t *testing.T) {
+		t.Parallel()
+
+		err := &RetryAfterError{Delay: 5 * time.Second, Err: errors.New("unexpected status code: got 503, expected one of [200]")}
+		assert.EqualError(t, err, "unexpected status code: got 503, expected one of [200] (retry after 5s)")
+	})
+}
@@ -2,8 +2,12 @@ package checker
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"net/url"
 	"time"
+
+	"golang.org/x/net/proxy"
 )
 
 const defaultTCPTimeout time.Duration = 2 * time.Second
@@ -13,6 +17,13 @@ type TCPChecker struct {
 	name    string
 	address string
 	dialer  *net.Dialer
+
+	resolveOverrides resolveOverrides
+	proxy            *url.URL
+	proxyDialer      proxy.ContextDialer // proxyDialer connects through proxy when set.
+	source           sourceBinding
+	dns              dnsSettings
+	multiAddress     multiAddress
 }
 
 // Address returns the checker address.
@@ -26,7 +37,33 @@ func (c *TCPChecker) Type() string { return TCP.String() }
 
 // Check performs the checker operation.
 func (c *TCPChecker) Check(ctx context.Context) error {
-	conn, err := c.dialer.DialContext(ctx, "tcp", c.address)
+	if c.multiAddress.enabled() {
+		host, port, err := net.SplitHostPort(c.address)
+		if err != nil {
+			return &PermanentError{Err: fmt.Errorf("invalid address %q: %w", c.address, err)}
+		}
+		return c.multiAddress.check(ctx, host, func(ctx context.Context, ip net.IP) error {
+			return c.dial(ctx, net.JoinHostPort(ip.String(), port))
+		})
+	}
+
+	return c.dial(ctx, c.address)
+}
+
+// dial opens and closes a TCP connection to address.
+func (c *TCPChecker) dial(ctx context.Context, address string) error {
+	address = c.resolveOverrides.address(address)
+
+	var conn net.Conn
+	var err error
+	if c.proxyDialer != nil {
+		// The dialer timeout only covers the connection to the proxy, not the handshake.
+		ctx, cancel := context.WithTimeout(ctx, c.dialer.Timeout)
+		defer cancel()
+		conn, err = c.proxyDialer.DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = c.dialer.DialContext(ctx, "tcp", address)
+	}
 	if err != nil {
 		return err
 	}
@@ -35,7 +72,7 @@ func (c *TCPChecker) Check(ctx context.Context) error {
 }
 
 // newTCPChecker creates a new TCPChecker with functional options.
-func newTCPChecker(name, address string, opts ...Option) (*TCPChecker, error) { // nolint:unparam
+func newTCPChecker(name, address string, opts ...Option) (*TCPChecker, error) {
 	checker := &TCPChecker{
 		name:    name,
 		address: address,
@@ -47,6 +84,17 @@ func newTCPChecker(name, address string, opts ...Option) (*TCPChecker, error) {
 	for _, opt := range opts {
 		opt.apply(checker)
 	}
+	checker.source.apply(checker.dialer, "tcp")
+	checker.dialer.Resolver = checker.dns.resolver(checker.source)
+	checker.multiAddress.resolver = checker.dialer.Resolver
+
+	if checker.proxy != nil {
+		proxyDialer, err := newTCPProxyDialer(checker.proxy, checker.dialer)
+		if err != nil {
+			return nil, err
+		}
+		checker.proxyDialer = proxyDialer
+	}
 
 	return checker, nil
 }
@@ -16,6 +16,22 @@ func registerAppFlags(tf *tinyflags.FlagSet, cfg *Config) {
 		Placeholder("N").
 		Value()
 
+	tf.BoolVar(&cfg.Once, "once", false, "Check every target exactly once without retries and exit. Intended for exec-based Kubernetes probes.").
+		Value()
+
+	tf.StringVar(&cfg.ReportFile, "report-file", "", "Write a JSON summary of the run to this path, even on failure. Disabled when empty.").
+		Placeholder("PATH").
+		Value()
+
+	tf.StringVar(
+		&cfg.TerminationMessagePath,
+		"termination-message-path",
+		DefaultTerminationMessagePath,
+		"Write a failure summary to this path. The default path is only used when it exists. Disabled when empty.",
+	).
+		Placeholder("PATH").
+		Value()
+
 	tinyflags.EnumVar(
 		tf,
 		&cfg.LogFormat,
@@ -104,3 +104,221 @@ func TestParseFlagsLogFormat(t *testing.T) {
 		assertInvalidFlagValueError(t, err, "--log-format", "xml", "json", "text")
 	})
 }
+
+// TestParseFlagsCommand verifies arguments after "--" are returned as the command to execute.
+func TestParseFlagsCommand(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with command", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{"--max-attempts=3", "--", "app", "--max-attempts=5", "serve"}, "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, 3, parsedFlags.MaxAttempts)
+		assert.Equal(t, []string{"app", "--max-attempts=5", "serve"}, parsedFlags.Command)
+	})
+
+	t.Run("without command", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{"--max-attempts=3"}, "1.0.0")
+		require.NoError(t, err)
+		assert.Nil(t, parsedFlags.Command)
+	})
+
+	t.Run("empty command", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{"--max-attempts=3", "--"}, "1.0.0")
+		require.NoError(t, err)
+		assert.Nil(t, parsedFlags.Command)
+	})
+
+	t.Run("separator as flag value", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{httpWebAddressFlag, "--http.web.header", "--", "app"}, "1.0.0")
+		require.Error(t, err)
+		assert.EqualError(t, err, "missing value for flag --http.web.header")
+	})
+
+	t.Run("argument before separator", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{"--max-attempts=3", "app", "--", "serve"}, "1.0.0")
+		require.Error(t, err)
+		assert.EqualError(t, err, `unexpected argument "app": pass the command after "--"`)
+	})
+}
+
+// TestParseFlagsMonitor verifies monitor mode flags and their validation.
+func TestParseFlagsMonitor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{}, "1.0.0")
+		require.NoError(t, err)
+		assert.False(t, parsedFlags.Monitor)
+		assert.Equal(t, ":8080", parsedFlags.ListenAddress)
+		assert.Equal(t, 1, parsedFlags.SuccessThreshold)
+		assert.Equal(t, 3, parsedFlags.FailureThreshold)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{
+			"--monitor",
+			"--listen-address=127.0.0.1:9000",
+			"--success-threshold=2",
+			"--failure-threshold=5",
+		}, "1.0.0")
+		require.NoError(t, err)
+		assert.True(t, parsedFlags.Monitor)
+		assert.Equal(t, "127.0.0.1:9000", parsedFlags.ListenAddress)
+		assert.Equal(t, 2, parsedFlags.SuccessThreshold)
+		assert.Equal(t, 5, parsedFlags.FailureThreshold)
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{"--failure-threshold=0"}, "1.0.0")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failure-threshold must be positive")
+	})
+
+	t.Run("command conflict", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{"--monitor", "--", "app"}, "1.0.0")
+		require.Error(t, err)
+		assert.EqualError(t, err, "--monitor cannot be combined with a command after \"--\"")
+	})
+}
+
+// TestParseFlagsMetrics verifies metrics flags and their validation.
+func TestParseFlagsMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{}, "1.0.0")
+		require.NoError(t, err)
+		assert.Empty(t, parsedFlags.MetricsAddress)
+		assert.Empty(t, parsedFlags.MetricsPushURL)
+		assert.Equal(t, "never", parsedFlags.MetricsPushJob)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{
+			"--metrics-address=:9090",
+			"--metrics-push-url=http://pushgateway:9091",
+			"--metrics-push-job=init",
+		}, "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, ":9090", parsedFlags.MetricsAddress)
+		assert.Equal(t, "http://pushgateway:9091", parsedFlags.MetricsPushURL)
+		assert.Equal(t, "init", parsedFlags.MetricsPushJob)
+	})
+
+	t.Run("invalid push url", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{"--metrics-push-url=ftp://pushgateway"}, "1.0.0")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported scheme")
+	})
+}
+
+// TestParseFlagsReportFile verifies the report file flag is parsed.
+func TestParseFlagsReportFile(t *testing.T) {
+	t.Parallel()
+
+	parsedFlags, err := ParseFlags([]string{"--report-file=/tmp/report.json"}, "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/report.json", parsedFlags.ReportFile)
+}
+
+// TestParseFlagsTerminationMessagePath verifies the termination message path defaults to the Kubernetes path.
+func TestParseFlagsTerminationMessagePath(t *testing.T) {
+	t.Parallel()
+
+	parsedFlags, err := ParseFlags([]string{}, "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultTerminationMessagePath, parsedFlags.TerminationMessagePath)
+
+	parsedFlags, err = ParseFlags([]string{"--termination-message-path="}, "1.0.0")
+	require.NoError(t, err)
+	assert.Empty(t, parsedFlags.TerminationMessagePath)
+}
+
+// TestParseFlagsValidate verifies the validate subcommand and its output format.
+func TestParseFlagsValidate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("subcommand", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{"validate", "--output=json", httpWebAddressFlag}, "1.0.0")
+		require.NoError(t, err)
+		assert.True(t, parsedFlags.Validate)
+		assert.Equal(t, OutputJSON, parsedFlags.Output)
+		require.Len(t, parsedFlags.Targets, 1)
+	})
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{httpWebAddressFlag}, "1.0.0")
+		require.NoError(t, err)
+		assert.False(t, parsedFlags.Validate)
+		assert.Equal(t, OutputTable, parsedFlags.Output)
+	})
+
+	t.Run("invalid output", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{"validate", "--output=yaml"}, "1.0.0")
+		assertInvalidFlagValueError(t, err, "--output", "yaml", "table", "json")
+	})
+
+	t.Run("with command", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{"validate", httpWebAddressFlag, "--", "app"}, "1.0.0")
+		require.EqualError(t, err, "validate cannot be combined with a command after \"--\"")
+	})
+}
+
+// TestParseFlagsOnce verifies --once parsing and its conflicts with other modes.
+func TestParseFlagsOnce(t *testing.T) {
+	t.Parallel()
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{"--once", httpWebAddressFlag}, "1.0.0")
+		require.NoError(t, err)
+		assert.True(t, parsedFlags.Once)
+	})
+
+	t.Run("with monitor", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{"--once", "--monitor", httpWebAddressFlag}, "1.0.0")
+		require.EqualError(t, err, "--once cannot be combined with --monitor")
+	})
+
+	t.Run("with command", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{"--once", httpWebAddressFlag, "--", "app"}, "1.0.0")
+		require.EqualError(t, err, "--once cannot be combined with a command after \"--\"")
+	})
+}
@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/containeroo/tinyflags"
+	"gopkg.in/yaml.v3"
+)
+
+// targetsKey is the config file key holding the list of targets.
+const targetsKey string = "targets"
+
+// configIgnoredFlags are static flags that cannot be set from a config file.
+var configIgnoredFlags = []string{"help", "version", "config"}
+
+// configError points to the file, line and key of an invalid config file entry.
+type configError struct {
+	Path string
+	Line int
+	Key  string
+	Err  error
+}
+
+// Error implements error.
+func (e *configError) Error() string {
+	return fmt.Sprintf("%s:%d: %s: %v", e.Path, e.Line, e.Key, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *configError) Unwrap() error { return e.Err }
+
+// registerConfigFlags registers the config file flag and binds it to cfg.
+func registerConfigFlags(tf *tinyflags.FlagSet, cfg *Config) {
+	tf.StringVar(&cfg.ConfigFile, "config", "", "YAML or JSON file with targets and global settings. Flags and environment variables take precedence.").
+		Placeholder("PATH").
+		Value()
+}
+
+// applyConfigFile merges the config file at path into the parsed flag set.
+// Values already set by flags or environment variables are kept.
+func applyConfigFile(tf *tinyflags.FlagSet, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return &configError{Path: path, Line: root.Line, Key: "<root>", Err: errors.New("expected a mapping")}
+	}
+
+	return forEachKey(path, root, "", func(key string, keyNode, value *yaml.Node) error {
+		if key == targetsKey {
+			return applyConfigTargets(tf, path, value)
+		}
+		return applyConfigGlobal(tf, path, keyNode, value)
+	})
+}
+
+// applyConfigGlobal sets a static flag from the config file unless it was already set.
+func applyConfigGlobal(tf *tinyflags.FlagSet, path string, keyNode, value *yaml.Node) error {
+	key := keyNode.Value
+	fl := tf.LookupFlag(key)
+	if fl == nil || slices.Contains(configIgnoredFlags, key) {
+		return &configError{Path: path, Line: keyNode.Line, Key: key, Err: errors.New("unknown key")}
+	}
+	if fl.Value.Changed() {
+		return nil
+	}
+	if value.Kind != yaml.ScalarNode {
+		return &configError{Path: path, Line: value.Line, Key: key, Err: errors.New("expected a scalar value")}
+	}
+	if err := fl.Value.Set(value.Value); err != nil {
+		return &configError{Path: path, Line: value.Line, Key: key, Err: err}
+	}
+
+	return nil
+}
+
+// applyConfigTargets adds the targets listed in the config file to the dynamic groups.
+func applyConfigTargets(tf *tinyflags.FlagSet, path string, list *yaml.Node) error {
+	if list.Kind != yaml.SequenceNode {
+		return &configError{Path: path, Line: list.Line, Key: targetsKey, Err: errors.New("expected a list")}
+	}
+
+	seen := make(map[string]int)
+	for i, node := range list.Content {
+		prefix := fmt.Sprintf("%s[%d]", targetsKey, i)
+		if node.Kind != yaml.MappingNode {
+			return &configError{Path: path, Line: node.Line, Key: prefix, Err: errors.New("expected a mapping")}
+		}
+
+		typeNode := lookupKey(node, "type")
+		if typeNode == nil || typeNode.Value == "" {
+			return &configError{Path: path, Line: node.Line, Key: prefix + ".type", Err: errors.New("is required")}
+		}
+		group := lookupDynamicGroup(tf, typeNode.Value)
+		if group == nil {
+			return &configError{Path: path, Line: typeNode.Line, Key: prefix + ".type", Err: fmt.Errorf("unsupported check type %q", typeNode.Value)}
+		}
+
+		idNode := lookupKey(node, "id")
+		if idNode == nil || idNode.Value == "" {
+			return &configError{Path: path, Line: node.Line, Key: prefix + ".id", Err: errors.New("is required")}
+		}
+		id := idNode.Value
+		if prev, ok := seen[group.Name()+"."+id]; ok {
+			return &configError{Path: path, Line: idNode.Line, Key: prefix + ".id", Err: fmt.Errorf("duplicate %s target %q (first defined on line %d)", group.Name(), id, prev)}
+		}
+		seen[group.Name()+"."+id] = idNode.Line
+
+		if err := applyConfigTarget(path, prefix, group, id, node); err != nil {
+			return err
+		}
+
+		if _, ok := group.Items()["address"].Value.GetAny(id); !ok {
+			return &configError{Path: path, Line: node.Line, Key: prefix + ".address", Err: errors.New("is required")}
+		}
+	}
+
+	return nil
+}
+
+// applyConfigTarget sets the properties of one config file target unless they were already set.
+func applyConfigTarget(path, prefix string, group *tinyflags.DynamicGroup, id string, node *yaml.Node) error {
+	items := group.Items()
+
+	return forEachKey(path, node, prefix+".", func(key string, keyNode, value *yaml.Node) error {
+		if key == "type" || key == "id" {
+			return nil
+		}
+
+		item, ok := items[key]
+		if !ok {
+			return &configError{Path: path, Line: keyNode.Line, Key: prefix + "." + key, Err: fmt.Errorf("unknown %s property", group.Name())}
+		}
+		if _, changed := item.Value.GetAny(id); changed {
+			return nil
+		}
+
+		raws, err := scalarValues(value)
+		if err != nil {
+			return &configError{Path: path, Line: value.Line, Key: prefix + "." + key, Err: err}
+		}
+		for _, raw := range raws {
+			if err := item.Value.Set(id, raw.Value); err != nil {
+				return &configError{Path: path, Line: raw.Line, Key: prefix + "." + key, Err: err}
+			}
+		}
+
+		return nil
+	})
+}
+
+// forEachKey calls fn for each key of a mapping node and rejects duplicate keys.
+func forEachKey(path string, node *yaml.Node, prefix string, fn func(key string, keyNode, value *yaml.Node) error) error {
+	seen := make(map[string]bool, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, value := node.Content[i], node.Content[i+1]
+		if seen[keyNode.Value] {
+			return &configError{Path: path, Line: keyNode.Line, Key: prefix + keyNode.Value, Err: errors.New("duplicate key")}
+		}
+		seen[keyNode.Value] = true
+
+		if err := fn(keyNode.Value, keyNode, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lookupKey returns the value node of key in a mapping node or nil when missing.
+func lookupKey(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// lookupDynamicGroup returns the dynamic group for a check type or nil when unknown.
+func lookupDynamicGroup(tf *tinyflags.FlagSet, name string) *tinyflags.DynamicGroup {
+	for _, g := range tf.DynamicGroups() {
+		if g.Name() == name {
+			return g
+		}
+	}
+
+	return nil
+}
+
+// scalarValues returns the scalar nodes of a scalar or a list of scalars.
+func scalarValues(node *yaml.Node) ([]*yaml.Node, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return []*yaml.Node{node}, nil
+	case yaml.SequenceNode:
+		for _, n := range node.Content {
+			if n.Kind != yaml.ScalarNode {
+				return nil, errors.New("expected a list of scalar values")
+			}
+		}
+		return node.Content, nil
+	default:
+		return nil, errors.New("expected a scalar value or a list")
+	}
+}
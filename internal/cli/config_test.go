@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containeroo/never/internal/checker"
+	"github.com/containeroo/never/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfig writes content to a config file in a temporary directory and returns its path.
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+// TestParseFlagsConfigFile verifies targets and global settings are read from a config file.
+func TestParseFlagsConfigFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("yaml", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeConfig(t, "never.yaml", `
+default-interval: 5s
+log-format: text
+targets:
+  - type: http
+    id: web
+    address: http://example.com
+    header:
+      - Authorization=Bearer token
+      - X-Test=1
+    expected-status-codes: [200-299]
+  - type: tcp
+    id: db
+    name: database
+    address: db:5432
+    max-attempts: 3
+`)
+
+		cfg, err := ParseFlags([]string{"--config=" + path}, "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Second, cfg.DefaultCheckInterval)
+		assert.Equal(t, logging.LogFormatText, cfg.LogFormat)
+		require.Len(t, cfg.Targets, 2)
+
+		web := cfg.Targets[0]
+		assert.Equal(t, checker.HTTP, web.Type)
+		assert.Equal(t, "web", web.Name)
+		assert.Equal(t, "http://example.com", web.Address)
+		assert.Equal(t, []string{"Authorization=Bearer token", "X-Test=1"}, web.HTTPHeaders)
+		assert.Equal(t, []string{"200-299"}, web.HTTPExpectedStatusCodes)
+
+		db := cfg.Targets[1]
+		assert.Equal(t, checker.TCP, db.Type)
+		assert.Equal(t, "database", db.Name)
+		assert.Equal(t, "db:5432", db.Address)
+		assert.Equal(t, 3, db.MaxAttempts)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeConfig(t, "never.json", `{
+  "max-attempts": 10,
+  "targets": [
+    {"type": "icmp", "id": "gw", "address": "127.0.0.1", "timeout": "1s"}
+  ]
+}`)
+
+		cfg, err := ParseFlags([]string{"--config=" + path}, "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, 10, cfg.MaxAttempts)
+		require.Len(t, cfg.Targets, 1)
+		assert.Equal(t, checker.ICMP, cfg.Targets[0].Type)
+		assert.Equal(t, time.Second, cfg.Targets[0].ICMPTimeout)
+	})
+
+	t.Run("flags win", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeConfig(t, "never.yaml", `
+max-attempts: 10
+targets:
+  - type: http
+    id: web
+    address: http://example.com
+    interval: 5s
+`)
+
+		cfg, err := ParseFlags([]string{
+			"--config=" + path,
+			"--max-attempts=3",
+			"--http.web.interval=1s",
+			"--http.api.address=http://api.example.com",
+		}, "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, 3, cfg.MaxAttempts)
+		require.Len(t, cfg.Targets, 2)
+		assert.Equal(t, "api", cfg.Targets[0].ID)
+		assert.Equal(t, "web", cfg.Targets[1].ID)
+		assert.Equal(t, "http://example.com", cfg.Targets[1].Address)
+		assert.Equal(t, time.Second, cfg.Targets[1].Interval)
+	})
+}
+
+// TestParseFlagsConfigFileErrors verifies invalid config files report the file, line and key.
+func TestParseFlagsConfigFileErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "validator",
+			content: "targets:\n  - type: http\n    id: web\n    address: ftp://example.com\n",
+			want:    `:4: targets[0].address: unsupported scheme: "ftp"`,
+		},
+		{
+			name:    "global validator",
+			content: "max-attempts: 0\n",
+			want:    ":1: max-attempts: max-attempts must be -1 or positive",
+		},
+		{
+			name:    "unknown key",
+			content: "unknown: 1\n",
+			want:    ":1: unknown: unknown key",
+		},
+		{
+			name:    "unknown property",
+			content: "targets:\n  - type: tcp\n    id: db\n    address: db:5432\n    method: GET\n",
+			want:    ":5: targets[0].method: unknown tcp property",
+		},
+		{
+			name:    "missing address",
+			content: "targets:\n  - type: tcp\n    id: db\n",
+			want:    ":2: targets[0].address: is required",
+		},
+		{
+			name:    "unsupported type",
+			content: "targets:\n  - type: udp\n    id: db\n",
+			want:    `:2: targets[0].type: unsupported check type "udp"`,
+		},
+		{
+			name:    "duplicate target",
+			content: "targets:\n  - type: tcp\n    id: db\n    address: db:5432\n  - type: tcp\n    id: db\n    address: db:5433\n",
+			want:    `:6: targets[1].id: duplicate tcp target "db" (first defined on line 3)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := writeConfig(t, "never.yaml", tt.content)
+
+			_, err := ParseFlags([]string{"--config=" + path}, "1.0.0")
+			require.Error(t, err)
+			assert.EqualError(t, err, path+tt.want)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{"--config=" + filepath.Join(t.TempDir(), "missing.yaml")}, "1.0.0")
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+// TestParseFlagsAddressRequired verifies targets without an address are rejected.
+func TestParseFlagsAddressRequired(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseFlags([]string{"--http.web.interval=1s"}, "1.0.0")
+	require.Error(t, err)
+	assert.EqualError(t, err, "flag --http.web.address is required")
+}
@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/containeroo/never/internal/exitcode"
 	"github.com/containeroo/never/internal/factory"
 	"github.com/containeroo/never/internal/logging"
 	"github.com/containeroo/tinyflags"
@@ -12,17 +15,38 @@ const (
 	defaultCheckInterval             time.Duration = 2 * time.Second
 	defaultHTTPAllowDuplicateHeaders bool          = false
 	defaultHTTPSkipTLSVerify         bool          = false
+	defaultHTTPFollowRedirects       bool          = true
+	defaultHTTPMaxRedirects          int           = 10
 )
 
+// DefaultTerminationMessagePath is the Kubernetes default terminationMessagePath.
+const DefaultTerminationMessagePath string = "/dev/termination-log"
+
 // Config holds the parsed command-line configuration.
 type Config struct {
-	ShowHelp             bool
-	ShowVersion          bool
-	Version              string
-	DefaultCheckInterval time.Duration
-	MaxAttempts          int
-	LogFormat            logging.LogFormat
-	Targets              []factory.TargetConfig
+	ShowHelp               bool
+	ConfigFile             string
+	ShowVersion            bool
+	Version                string
+	DefaultCheckInterval   time.Duration
+	MaxAttempts            int
+	Once                   bool
+	LogFormat              logging.LogFormat
+	ReportFile             string
+	TerminationMessagePath string
+	Targets                []factory.TargetConfig
+	Monitor                bool
+	ListenAddress          string
+	SuccessThreshold       int
+	FailureThreshold       int
+	MetricsAddress         string
+	MetricsPushURL         string
+	MetricsPushJob         string
+	// Validate checks the configuration and prints the effective targets without checking them.
+	Validate bool
+	Output   OutputFormat
+	// Command is executed in place of never once all targets are ready.
+	Command []string
 }
 
 // ParseFlags parses command-line arguments and returns application configuration.
@@ -37,17 +61,51 @@ func ParseFlags(args []string, version string) (*Config, error) {
 	tf.SortedGroups()
 
 	tf.Note("\nFlags can also be set through environment variables with the NEVER__ prefix. " +
-		"For example, --default-interval becomes NEVER__DEFAULT_INTERVAL and --http.web.address becomes NEVER__HTTP_WEB_ADDRESS.")
+		"For example, --default-interval becomes NEVER__DEFAULT_INTERVAL and --http.web.address becomes NEVER__HTTP_WEB_ADDRESS.\n\n" +
+		"Pass a command after \"--\" (never [flags] -- command [args...]) to replace never with it once all targets are ready.\n\n" +
+		"Run \"never validate [flags]\" to check the configuration and print the effective targets without checking them.\n\n" +
+		exitcode.Help())
 
+	registerConfigFlags(tf, &cfg)
 	registerAppFlags(tf, &cfg)
+	registerMonitorFlags(tf, &cfg)
+	registerMetricsFlags(tf, &cfg)
+	registerValidateFlags(tf, &cfg)
 	registerHTTPFlags(tf)
 	registerTCPFlags(tf)
 	registerICMPFlags(tf)
 
+	args, cfg.Validate = splitSubcommand(args)
+
 	if err := tf.Parse(args); err != nil {
 		return nil, err
 	}
 
+	command, err := commandArgs(args, tf.Args())
+	if err != nil {
+		return nil, err
+	}
+	cfg.Command = command
+
+	if cfg.ConfigFile != "" {
+		if err := applyConfigFile(tf, cfg.ConfigFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Monitor && len(cfg.Command) > 0 {
+		return nil, errors.New("--monitor cannot be combined with a command after \"--\"")
+	}
+	if cfg.Once && cfg.Monitor {
+		return nil, errors.New("--once cannot be combined with --monitor")
+	}
+	if cfg.Once && len(cfg.Command) > 0 {
+		return nil, errors.New("--once cannot be combined with a command after \"--\"")
+	}
+	if cfg.Validate && len(cfg.Command) > 0 {
+		return nil, errors.New("validate cannot be combined with a command after \"--\"")
+	}
+
 	targets, err := parseTargetConfigs(tf.DynamicGroups())
 	if err != nil {
 		return nil, err
@@ -56,3 +114,18 @@ func ParseFlags(args []string, version string) (*Config, error) {
 
 	return &cfg, nil
 }
+
+// commandArgs returns the command to exec from the positional arguments left by the parser.
+// The parser stops at the first "--" that is not a flag value, so the command must directly follow it.
+func commandArgs(args, positional []string) ([]string, error) {
+	if len(positional) == 0 {
+		return nil, nil
+	}
+
+	idx := len(args) - len(positional)
+	if idx == 0 || args[idx-1] != "--" {
+		return nil, fmt.Errorf("unexpected argument %q: pass the command after \"--\"", positional[0])
+	}
+
+	return positional, nil
+}
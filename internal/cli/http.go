@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/containeroo/never/internal/checker"
 	"github.com/containeroo/tinyflags"
 )
 
@@ -24,9 +25,8 @@ func registerHTTPFlags(tf *tinyflags.FlagSet) {
 		http.MethodTrace,
 	).
 		Placeholder("METHOD")
-	httpGroup.String("address", "", "HTTP target URL").
-		Validate(validateHTTPAddress).
-		Required()
+	httpGroup.String("address", "", "HTTP target URL (required)").
+		Validate(validateHTTPAddress)
 	httpGroup.Duration("interval", 0*time.Second, "Time between HTTP requests. Defaults to --default-interval when unset or 0.").
 		Validate(validateNonNegativeDuration("interval")).
 		Placeholder("DURATION")
@@ -34,6 +34,10 @@ func registerHTTPFlags(tf *tinyflags.FlagSet) {
 		Validate(validateOptionalMaxAttempts).
 		Placeholder("N")
 	registerRetryFlags(httpGroup)
+	registerLatencyFlags(httpGroup)
+	registerResolveFlags(httpGroup)
+	registerResolveOverrideFlags(httpGroup)
+	registerSourceFlags(httpGroup)
 	httpGroup.StringSlice("header", []string{}, "HTTP headers to send").
 		Placeholder("KEY=VALUE)")
 	httpGroup.Bool("allow-duplicate-headers", defaultHTTPAllowDuplicateHeaders, "Allow duplicate HTTP headers")
@@ -44,7 +48,39 @@ func registerHTTPFlags(tf *tinyflags.FlagSet) {
 	).
 		Validate(validateHTTPStatusCodes).
 		Placeholder("CODES...")
+	httpGroup.StringSlice(
+		"fail-fast-status-codes",
+		[]string{},
+		"HTTP status codes that stop retrying the target immediately, eg \"401,403\". Same format as --http.<ID>.expected-status-codes",
+	).
+		Validate(validateHTTPStatusCodes).
+		Placeholder("CODES...")
+	httpGroup.Bool("fail-fast-tls", false, "Stop retrying the target immediately when its TLS certificate cannot be verified")
 
+	// Strict, so --http.<ID>.follow-redirects=false is not parsed as true like a bare bool flag.
+	httpGroup.Bool("follow-redirects", defaultHTTPFollowRedirects,
+		"Follow redirects. When false, the redirect status code is checked against --http.<ID>.expected-status-codes").
+		Strict()
+	httpGroup.Int("max-redirects", defaultHTTPMaxRedirects, "Maximum number of redirects to follow").
+		Validate(validatePositiveInt("max-redirects")).
+		Placeholder("N")
+	httpGroup.String("expected-final-url", "", "URL the request must end at after following redirects. Not checked when unset.").
+		Validate(validateOptionalURL).
+		Placeholder("URL")
+	httpGroup.String("proxy", "", "Proxy URL (http, https, socks5 or socks5h) used instead of the proxy from the environment. socks5 resolves the hostname on the proxy like socks5h. Can be resolved.").
+		Validate(validateProxyURL(checker.HTTPProxySchemes)).
+		Placeholder("URL")
+	httpGroup.Bool("no-proxy", false, "Ignore the proxy from the environment (HTTP_PROXY, HTTPS_PROXY)")
+	// Dynamic flags have exactly three dot-separated parts, so "oauth2.token-url" cannot be a flag name.
+	httpGroup.String("oauth2-token-url", "", "Token endpoint of the OAuth2 client credentials grant, reached like the target but always with TLS verification. A bearer token is fetched and sent when set. Can be resolved.").
+		Validate(validateOptionalHTTPAddress).
+		Placeholder("URL")
+	httpGroup.String("oauth2-client-id", "", "OAuth2 client ID. Can be resolved.").
+		Placeholder("ID")
+	httpGroup.String("oauth2-client-secret", "", "OAuth2 client secret. Can be resolved.").
+		Placeholder("SECRET")
+	httpGroup.StringSlice("oauth2-scopes", []string{}, "OAuth2 scopes to request").
+		Placeholder("SCOPES...")
 	httpGroup.Bool("skip-tls-verify", defaultHTTPSkipTLSVerify, "Skip TLS verification")
 	httpGroup.Duration("timeout", 2*time.Second, "Request timeout").
 		Validate(validateTimeoutDuration()).
@@ -114,3 +114,150 @@ func TestParseFlagsHTTPPerTargetMaxAttempts(t *testing.T) {
 	assert.Equal(t, 5, parsedFlags.MaxAttempts)
 	assert.Equal(t, 2, parsedFlags.Targets[0].MaxAttempts)
 }
+
+// TestParseFlagsHTTPFailFastStatusCodes verifies fail-fast status codes are parsed and validated.
+func TestParseFlagsHTTPFailFastStatusCodes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{
+			httpWebAddressFlag,
+			"--http.web.fail-fast-status-codes=401,403",
+			"--http.web.fail-fast-tls",
+		}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.Equal(t, []string{"401", "403"}, parsedFlags.Targets[0].HTTPFailFastStatusCodes)
+		assert.True(t, parsedFlags.Targets[0].HTTPFailFastTLS)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{
+			httpWebAddressFlag,
+			"--http.web.fail-fast-status-codes=abc",
+		}, "1.0.0")
+		require.Error(t, err)
+	})
+}
+
+// TestParseFlagsHTTPRedirects verifies the redirect policy flags are parsed and validated.
+func TestParseFlagsHTTPRedirects(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{httpWebAddressFlag}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.True(t, parsedFlags.Targets[0].HTTPFollowRedirects)
+		assert.Equal(t, 10, parsedFlags.Targets[0].HTTPMaxRedirects)
+		assert.Empty(t, parsedFlags.Targets[0].HTTPExpectedFinalURL)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{
+			httpWebAddressFlag,
+			"--http.web.follow-redirects=false",
+			"--http.web.max-redirects=3",
+			"--http.web.expected-final-url=https://example.com/health",
+		}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.False(t, parsedFlags.Targets[0].HTTPFollowRedirects)
+		assert.Equal(t, 3, parsedFlags.Targets[0].HTTPMaxRedirects)
+		assert.Equal(t, "https://example.com/health", parsedFlags.Targets[0].HTTPExpectedFinalURL)
+	})
+
+	t.Run("follow redirects as number", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{httpWebAddressFlag, "--http.web.follow-redirects=0"}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.False(t, parsedFlags.Targets[0].HTTPFollowRedirects)
+	})
+
+	for _, arg := range []string{"--http.web.max-redirects=0", "--http.web.expected-final-url=example.com"} {
+		t.Run(arg, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseFlags([]string{httpWebAddressFlag, arg}, "1.0.0")
+			require.Error(t, err)
+		})
+	}
+}
+
+// TestParseFlagsHTTPProxy verifies the proxy flags are parsed and validated.
+func TestParseFlagsHTTPProxy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{httpWebAddressFlag, "--http.web.proxy=socks5h://bastion:1080"}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.Equal(t, "socks5h://bastion:1080", parsedFlags.Targets[0].Proxy)
+		assert.False(t, parsedFlags.Targets[0].HTTPNoProxy)
+
+		parsedFlags, err = ParseFlags([]string{httpWebAddressFlag, "--http.web.proxy=env:PROXY_URL", "--tcp.db.address=db:5432", "--tcp.db.proxy=socks5://bastion"}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 2)
+
+		parsedFlags, err = ParseFlags([]string{httpWebAddressFlag, "--http.web.no-proxy"}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.True(t, parsedFlags.Targets[0].HTTPNoProxy)
+	})
+
+	for _, args := range [][]string{
+		{httpWebAddressFlag, "--http.web.proxy=ftp://proxy:21"},
+		{httpWebAddressFlag, "--http.web.proxy=proxy:3128"},
+		{"--tcp.db.address=db:5432", "--tcp.db.proxy=http://proxy:3128"},
+	} {
+		t.Run(args[len(args)-1], func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseFlags(args, "1.0.0")
+			require.Error(t, err)
+		})
+	}
+}
+
+// TestParseFlagsHTTPOAuth2 verifies the OAuth2 client credentials flags are parsed and validated.
+func TestParseFlagsHTTPOAuth2(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{
+			httpWebAddressFlag,
+			"--http.web.oauth2-token-url=https://idp.example.com/oauth2/token",
+			"--http.web.oauth2-client-id=never",
+			"--http.web.oauth2-client-secret=env:CLIENT_SECRET",
+			"--http.web.oauth2-scopes=health:read,metrics",
+		}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		target := parsedFlags.Targets[0]
+		assert.Equal(t, "https://idp.example.com/oauth2/token", target.HTTPOAuth2TokenURL)
+		assert.Equal(t, "never", target.HTTPOAuth2ClientID)
+		assert.Equal(t, "env:CLIENT_SECRET", target.HTTPOAuth2ClientSecret)
+		assert.Equal(t, []string{"health:read", "metrics"}, target.HTTPOAuth2Scopes)
+	})
+
+	t.Run("invalid token URL", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{httpWebAddressFlag, "--http.web.oauth2-token-url=idp.example.com/token"}, "1.0.0")
+		require.Error(t, err)
+	})
+}
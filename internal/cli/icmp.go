@@ -3,6 +3,7 @@ package cli
 import (
 	"time"
 
+	"github.com/containeroo/never/internal/checker"
 	"github.com/containeroo/tinyflags"
 )
 
@@ -10,16 +11,39 @@ import (
 func registerICMPFlags(tf *tinyflags.FlagSet) {
 	icmp := tf.DynamicGroup("icmp").Title("ICMP")
 	icmp.String("name", "", "Name of the ICMP checker. Defaults to <ID>.")
-	icmp.String("address", "", "ICMP target address").
-		Validate(validateICMPAddress).
-		Required()
+	icmp.String("address", "", "ICMP target address (required)").
+		Validate(validateICMPAddress)
 	icmp.Duration("interval", 0*time.Second, "Time between ICMP requests. Defaults to --default-interval when unset or 0.").
 		Validate(validateNonNegativeDuration("interval")).
 		Placeholder("DURATION")
 	icmp.Int("max-attempts", 0, "Maximum attempts before giving up. Defaults to --max-attempts when unset or 0.").
 		Validate(validateOptionalMaxAttempts).
 		Placeholder("N")
+	tinyflags.DynamicEnum(icmp, "ip-family", checker.IPFamilyAny, "IP family a hostname is resolved to.", checker.IPFamilyAny, checker.IPFamily4, checker.IPFamily6).
+		Placeholder("FAMILY")
+	tinyflags.DynamicEnum(icmp, "privileged", checker.ICMPPrivilegedAuto, "Use raw ICMP sockets (true, needs CAP_NET_RAW) or ping sockets (false, needs net.ipv4.ping_group_range). auto falls back to ping sockets.",
+		checker.ICMPPrivilegedAuto, checker.ICMPPrivilegedTrue, checker.ICMPPrivilegedFalse).
+		Placeholder("MODE")
+	icmp.Int("count", 1, "Number of echo requests sent per attempt.").
+		Validate(validatePositiveInt("count")).
+		Placeholder("N")
+	icmp.String("max-loss", "0%", "Highest tolerated packet loss per attempt, for example 20%.").
+		Validate(validatePercent).
+		Placeholder("PERCENT")
+	icmp.Duration("max-rtt", 0*time.Second, "Highest tolerated average round-trip time per attempt. Disabled when unset or 0.").
+		Validate(validateNonNegativeDuration("max-rtt")).
+		Placeholder("DURATION")
+	icmp.Int("size", 0, "Echo payload size in bytes, like ping -s. Defaults to a 15-byte payload when unset or 0.").
+		Validate(validateIntRange("size", 0, checker.MaxICMPPayloadSize)).
+		Placeholder("BYTES")
+	icmp.Int("ttl", 0, "IPv4 TTL or IPv6 hop limit of echo requests. Defaults to the system default when unset or 0.").
+		Validate(validateIntRange("ttl", 0, 255)).
+		Placeholder("N")
+	icmp.Bool("dont-fragment", false, "Forbid fragmentation of echo requests to verify the path MTU (Linux only).")
 	registerRetryFlags(icmp)
+	registerLatencyFlags(icmp)
+	registerResolveFlags(icmp)
+	registerSourceFlags(icmp)
 	icmp.Duration("timeout", 2*time.Second, "Timeout for ICMP read and write").
 		Validate(validateTimeoutDuration()).
 		Placeholder("DURATION")
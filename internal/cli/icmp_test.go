@@ -36,3 +36,135 @@ func TestParseFlagsICMPTimeout(t *testing.T) {
 	assert.Equal(t, 4*time.Second, parsedFlags.Targets[0].ICMPReadTimeout)
 	assert.Equal(t, 5*time.Second, parsedFlags.Targets[0].ICMPWriteTimeout)
 }
+
+// TestParseFlagsICMPIPFamily verifies the ICMP IP family is parsed, defaulted and validated.
+func TestParseFlagsICMPIPFamily(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{"--icmp.host.address=example.com"}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.Equal(t, checker.IPFamilyAny, parsedFlags.Targets[0].ICMPIPFamily)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{"--icmp.host.address=example.com", "--icmp.host.ip-family=6"}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.Equal(t, checker.IPFamily6, parsedFlags.Targets[0].ICMPIPFamily)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{"--icmp.host.address=example.com", "--icmp.host.ip-family=5"}, "1.0.0")
+		assertInvalidFlagValueError(t, err, "--icmp.host.ip-family", "5", checker.IPFamilyAny.String(), checker.IPFamily4.String(), checker.IPFamily6.String())
+	})
+}
+
+// TestParseFlagsICMPPrivileged verifies the ICMP socket mode is parsed, defaulted and validated.
+func TestParseFlagsICMPPrivileged(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{"--icmp.host.address=example.com"}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.Equal(t, checker.ICMPPrivilegedAuto, parsedFlags.Targets[0].ICMPPrivileged)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{"--icmp.host.address=example.com", "--icmp.host.privileged=false"}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.Equal(t, checker.ICMPPrivilegedFalse, parsedFlags.Targets[0].ICMPPrivileged)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{"--icmp.host.address=example.com", "--icmp.host.privileged=maybe"}, "1.0.0")
+		assertInvalidFlagValueError(t, err, "--icmp.host.privileged", "maybe",
+			checker.ICMPPrivilegedAuto.String(), checker.ICMPPrivilegedTrue.String(), checker.ICMPPrivilegedFalse.String())
+	})
+}
+
+// TestParseFlagsICMPBurst verifies the ICMP burst thresholds are parsed and validated.
+func TestParseFlagsICMPBurst(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{"--icmp.host.address=example.com"}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.Equal(t, 1, parsedFlags.Targets[0].ICMPCount)
+		assert.Zero(t, parsedFlags.Targets[0].ICMPMaxLoss)
+		assert.Zero(t, parsedFlags.Targets[0].ICMPMaxRTT)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{
+			"--icmp.host.address=example.com",
+			"--icmp.host.count=5",
+			"--icmp.host.max-loss=20%",
+			"--icmp.host.max-rtt=50ms",
+		}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.Equal(t, 5, parsedFlags.Targets[0].ICMPCount)
+		assert.InDelta(t, 20.0, parsedFlags.Targets[0].ICMPMaxLoss, 0.001)
+		assert.Equal(t, 50*time.Millisecond, parsedFlags.Targets[0].ICMPMaxRTT)
+	})
+
+	for _, arg := range []string{"--icmp.host.count=0", "--icmp.host.max-loss=120%", "--icmp.host.max-rtt=-1s"} {
+		t.Run(arg, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseFlags([]string{"--icmp.host.address=example.com", arg}, "1.0.0")
+			require.Error(t, err)
+		})
+	}
+}
+
+// TestParseFlagsICMPPacketOptions verifies the ICMP payload size, TTL and don't-fragment flags are parsed and validated.
+func TestParseFlagsICMPPacketOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{
+			"--icmp.vpn.address=10.8.0.1",
+			"--icmp.vpn.size=1472",
+			"--icmp.vpn.ttl=16",
+			"--icmp.vpn.dont-fragment",
+		}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.Equal(t, 1472, parsedFlags.Targets[0].ICMPSize)
+		assert.Equal(t, 16, parsedFlags.Targets[0].ICMPTTL)
+		assert.True(t, parsedFlags.Targets[0].ICMPDontFragment)
+	})
+
+	for _, arg := range []string{"--icmp.vpn.size=-1", "--icmp.vpn.size=65508", "--icmp.vpn.ttl=256"} {
+		t.Run(arg, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseFlags([]string{"--icmp.vpn.address=10.8.0.1", arg}, "1.0.0")
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/containeroo/tinyflags"
+)
+
+const (
+	defaultLatencyPercentile int = 100
+	defaultLatencyWindow     int = 1
+)
+
+// registerLatencyFlags registers flags that treat slow but successful checks as failures.
+func registerLatencyFlags(group *tinyflags.DynamicGroup) {
+	group.Duration("max-latency", 0*time.Second, "Treat successful checks slower than this as failed. Disabled when unset or 0.").
+		Validate(validateNonNegativeDuration("max-latency")).
+		Placeholder("DURATION")
+	group.Int("latency-percentile", defaultLatencyPercentile, "Percentile of the latencies over --<type>.<ID>.latency-window attempts compared with max-latency.").
+		Validate(validatePercentile).
+		Placeholder("P")
+	group.Int("latency-window", defaultLatencyWindow, "Number of successful attempts the latency percentile is calculated over.").
+		Validate(validatePositiveInt("latency-window")).
+		Placeholder("N")
+}
+
+// validatePercentile validates a percentile between 1 and 100.
+func validatePercentile(v int) error {
+	if v < 1 || v > 100 {
+		return fmt.Errorf("percentile must be between 1 and 100, got %d", v)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"github.com/containeroo/tinyflags"
+)
+
+const defaultMetricsPushJob string = "never"
+
+// registerMetricsFlags registers metrics flags and binds them to cfg.
+func registerMetricsFlags(tf *tinyflags.FlagSet, cfg *Config) {
+	tf.StringVar(&cfg.MetricsAddress, "metrics-address", "", "Address to serve Prometheus metrics on /metrics. Disabled when empty.").
+		Validate(validateOptionalListenAddress).
+		Placeholder("ADDR").
+		Value()
+
+	tf.StringVar(&cfg.MetricsPushURL, "metrics-push-url", "", "Pushgateway-compatible URL to push metrics to after the run. Disabled when empty.").
+		Validate(validateOptionalURL).
+		Placeholder("URL").
+		Value()
+
+	tf.StringVar(&cfg.MetricsPushJob, "metrics-push-job", defaultMetricsPushJob, "Job name used when pushing metrics.").
+		Placeholder("NAME").
+		Value()
+}
@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"github.com/containeroo/tinyflags"
+)
+
+const (
+	defaultListenAddress    string = ":8080"
+	defaultSuccessThreshold int    = 1
+	defaultFailureThreshold int    = 3
+)
+
+// registerMonitorFlags registers continuous monitoring flags and binds them to cfg.
+func registerMonitorFlags(tf *tinyflags.FlagSet, cfg *Config) {
+	tf.BoolVar(&cfg.Monitor, "monitor", false, "Keep checking all targets and serve /livez, /readyz and /status instead of exiting once ready.").
+		Value()
+
+	tf.StringVar(&cfg.ListenAddress, "listen-address", defaultListenAddress, "Address to serve health endpoints on in --monitor mode.").
+		Validate(validateListenAddress).
+		Placeholder("ADDR").
+		Value()
+
+	tf.IntVar(&cfg.SuccessThreshold, "success-threshold", defaultSuccessThreshold, "Consecutive successes before a target is considered ready in --monitor mode.").
+		Validate(validatePositiveInt("success-threshold")).
+		Placeholder("N").
+		Value()
+
+	tf.IntVar(&cfg.FailureThreshold, "failure-threshold", defaultFailureThreshold, "Consecutive failures before a ready target is considered not ready in --monitor mode.").
+		Validate(validatePositiveInt("failure-threshold")).
+		Placeholder("N").
+		Value()
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"github.com/containeroo/never/internal/checker"
+	"github.com/containeroo/tinyflags"
+)
+
+const defaultMinReadyAddresses int = 1
+
+// registerResolveFlags registers flags that control which resolved addresses of a hostname are checked.
+func registerResolveFlags(group *tinyflags.DynamicGroup) {
+	tinyflags.DynamicEnum(group, "resolve", checker.ResolveFirst, "Resolved addresses to check. any and all resolve the hostname on every attempt and check each address.",
+		checker.ResolveFirst, checker.ResolveAny, checker.ResolveAll).
+		Placeholder("MODE")
+	group.String("dns-server", "", "DNS server to resolve the hostname with, as IP or IP:PORT. Defaults to the name servers in /etc/resolv.conf.").
+		Validate(validateDNSServer).
+		Placeholder("ADDRESS")
+	group.Duration("dns-timeout", 0, "Timeout of each DNS query. Defaults to the resolver timeout when unset or 0.").
+		Validate(validateNonNegativeDuration("dns-timeout")).
+		Placeholder("DURATION")
+	group.Int("min-ready-addresses", defaultMinReadyAddresses, "Number of resolved addresses that must pass with resolve=any.").
+		Validate(validatePositiveInt("min-ready-addresses")).
+		Placeholder("N")
+}
+
+// registerResolveOverrideFlags registers the flag that connects to fixed IPs instead of resolving the hostname.
+func registerResolveOverrideFlags(group *tinyflags.DynamicGroup) {
+	group.StringSlice("resolve-override", []string{}, "Connect to IP instead of resolving HOST on PORT, like curl --resolve. Can be passed multiple times.").
+		Validate(validateResolveOverride).
+		Placeholder("HOST:PORT:IP")
+}
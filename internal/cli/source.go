@@ -0,0 +1,14 @@
+package cli
+
+import (
+	"github.com/containeroo/tinyflags"
+)
+
+// registerSourceFlags registers flags that bind the packets of a target to a local address and interface.
+func registerSourceFlags(group *tinyflags.DynamicGroup) {
+	group.String("source-address", "", "Local IP to send packets from. Defaults to the address chosen by the routing table.").
+		Validate(validateSourceAddress).
+		Placeholder("IP")
+	group.String("interface", "", "Network interface to send packets through, for example net1 (Linux only).").
+		Placeholder("NAME")
+}
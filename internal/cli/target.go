@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
@@ -22,14 +23,28 @@ func parseTargetConfigs(dynamicGroups []*tinyflags.DynamicGroup) ([]factory.Targ
 
 		for _, id := range group.Instances() {
 			target := factory.TargetConfig{
-				ID:          id,
-				Type:        checkType,
-				Name:        id,
-				Address:     tinyflags.GetOrDefaultDynamic[string](group, id, "address"),
-				Interval:    getDynamicDuration(group, id, "interval"),
-				MaxAttempts: getDynamicInt(group, id, "max-attempts"),
-				Backoff:     getDynamicBackoffMode(group, id, "backoff"),
-				MaxInterval: getDynamicDuration(group, id, "max-interval"),
+				ID:                id,
+				Type:              checkType,
+				Name:              id,
+				Address:           tinyflags.GetOrDefaultDynamic[string](group, id, "address"),
+				Interval:          getDynamicDuration(group, id, "interval"),
+				MaxAttempts:       getDynamicInt(group, id, "max-attempts"),
+				Backoff:           getDynamicBackoffMode(group, id, "backoff"),
+				MaxInterval:       getDynamicDuration(group, id, "max-interval"),
+				MaxLatency:        getDynamicDuration(group, id, "max-latency"),
+				LatencyPercentile: tinyflags.GetOrDefaultDynamic[int](group, id, "latency-percentile"),
+				LatencyWindow:     tinyflags.GetOrDefaultDynamic[int](group, id, "latency-window"),
+				Resolve:           getDynamicResolveMode(group, id, "resolve"),
+				MinReadyAddresses: tinyflags.GetOrDefaultDynamic[int](group, id, "min-ready-addresses"),
+				DNSServer:         tinyflags.GetOrDefaultDynamic[string](group, id, "dns-server"),
+				DNSTimeout:        getDynamicDuration(group, id, "dns-timeout"),
+				SourceAddress:     tinyflags.GetOrDefaultDynamic[string](group, id, "source-address"),
+				Interface:         tinyflags.GetOrDefaultDynamic[string](group, id, "interface"),
+			}
+
+			// Address is checked here instead of being a required flag so it can come from a config file.
+			if target.Address == "" {
+				return nil, fmt.Errorf("flag --%s.%s.address is required", group.Name(), id)
 			}
 
 			if name := tinyflags.GetOrDefaultDynamic[string](group, id, "name"); name != "" {
@@ -55,16 +70,38 @@ func applyTargetTypeConfig(target *factory.TargetConfig, group *tinyflags.Dynami
 		target.HTTPHeaders = tinyflags.GetOrDefaultDynamic[[]string](group, id, "header")
 		target.HTTPAllowDuplicateHeaders = tinyflags.GetOrDefaultDynamic[bool](group, id, "allow-duplicate-headers")
 		target.HTTPExpectedStatusCodes = tinyflags.GetOrDefaultDynamic[[]string](group, id, "expected-status-codes")
+		target.HTTPFailFastStatusCodes = tinyflags.GetOrDefaultDynamic[[]string](group, id, "fail-fast-status-codes")
+		target.HTTPFailFastTLS = tinyflags.GetOrDefaultDynamic[bool](group, id, "fail-fast-tls")
 		target.HTTPSkipTLSVerify = tinyflags.GetOrDefaultDynamic[bool](group, id, "skip-tls-verify")
+		target.HTTPFollowRedirects = tinyflags.GetOrDefaultDynamic[bool](group, id, "follow-redirects")
+		target.HTTPMaxRedirects = tinyflags.GetOrDefaultDynamic[int](group, id, "max-redirects")
+		target.HTTPExpectedFinalURL = tinyflags.GetOrDefaultDynamic[string](group, id, "expected-final-url")
+		target.ResolveOverrides = tinyflags.GetOrDefaultDynamic[[]string](group, id, "resolve-override")
+		target.Proxy = tinyflags.GetOrDefaultDynamic[string](group, id, "proxy")
+		target.HTTPNoProxy = tinyflags.GetOrDefaultDynamic[bool](group, id, "no-proxy")
+		target.HTTPOAuth2TokenURL = tinyflags.GetOrDefaultDynamic[string](group, id, "oauth2-token-url")
+		target.HTTPOAuth2ClientID = tinyflags.GetOrDefaultDynamic[string](group, id, "oauth2-client-id")
+		target.HTTPOAuth2ClientSecret = tinyflags.GetOrDefaultDynamic[string](group, id, "oauth2-client-secret")
+		target.HTTPOAuth2Scopes = tinyflags.GetOrDefaultDynamic[[]string](group, id, "oauth2-scopes")
 		target.HTTPTimeout = tinyflags.GetOrDefaultDynamic[time.Duration](group, id, "timeout")
 
 	case checker.TCP:
+		target.Proxy = tinyflags.GetOrDefaultDynamic[string](group, id, "proxy")
+		target.ResolveOverrides = tinyflags.GetOrDefaultDynamic[[]string](group, id, "resolve-override")
 		target.TCPTimeout = tinyflags.GetOrDefaultDynamic[time.Duration](group, id, "timeout")
 
 	case checker.ICMP:
 		target.ICMPTimeout = tinyflags.GetOrDefaultDynamic[time.Duration](group, id, "timeout")
 		target.ICMPReadTimeout = getDynamicDuration(group, id, "read-timeout")
 		target.ICMPWriteTimeout = getDynamicDuration(group, id, "write-timeout")
+		target.ICMPIPFamily = tinyflags.GetOrDefaultDynamic[checker.IPFamily](group, id, "ip-family")
+		target.ICMPPrivileged = tinyflags.GetOrDefaultDynamic[checker.ICMPPrivileged](group, id, "privileged")
+		target.ICMPCount = tinyflags.GetOrDefaultDynamic[int](group, id, "count")
+		target.ICMPMaxLoss, _ = parsePercent(tinyflags.GetOrDefaultDynamic[string](group, id, "max-loss")) // validated by the flag
+		target.ICMPMaxRTT = getDynamicDuration(group, id, "max-rtt")
+		target.ICMPSize = tinyflags.GetOrDefaultDynamic[int](group, id, "size")
+		target.ICMPTTL = tinyflags.GetOrDefaultDynamic[int](group, id, "ttl")
+		target.ICMPDontFragment = tinyflags.GetOrDefaultDynamic[bool](group, id, "dont-fragment")
 	}
 }
 
@@ -88,3 +125,12 @@ func getDynamicBackoffMode(group *tinyflags.DynamicGroup, id, name string) backo
 	}
 	return v
 }
+
+// getDynamicResolveMode returns the configured resolve mode or ResolveFirst when unset.
+func getDynamicResolveMode(group *tinyflags.DynamicGroup, id, name string) checker.ResolveMode {
+	v := tinyflags.GetOrDefaultDynamic[checker.ResolveMode](group, id, name)
+	if v == "" {
+		return checker.ResolveFirst
+	}
+	return v
+}
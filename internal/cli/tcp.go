@@ -3,6 +3,7 @@ package cli
 import (
 	"time"
 
+	"github.com/containeroo/never/internal/checker"
 	"github.com/containeroo/tinyflags"
 )
 
@@ -10,12 +11,14 @@ import (
 func registerTCPFlags(tf *tinyflags.FlagSet) {
 	tcp := tf.DynamicGroup("tcp").Title("TCP")
 	tcp.String("name", "", "Name of the TCP checker. Defaults to <ID>.")
-	tcp.String("address", "", "TCP target address").
-		Validate(validateTCPAddress).
-		Required()
+	tcp.String("address", "", "TCP target address (required)").
+		Validate(validateTCPAddress)
 	tcp.Duration("timeout", 2*time.Second, "Timeout for TCP connection").
 		Validate(validateTimeoutDuration()).
 		Placeholder("DURATION")
+	tcp.String("proxy", "", "SOCKS5 proxy URL (socks5 or socks5h, both resolve the hostname on the proxy) to connect through. Can be resolved.").
+		Validate(validateProxyURL(checker.TCPProxySchemes)).
+		Placeholder("URL")
 	tcp.Duration("interval", 0*time.Second, "Time between TCP requests. Defaults to --default-interval when unset or 0.").
 		Validate(validateNonNegativeDuration("interval")).
 		Placeholder("DURATION")
@@ -23,4 +26,8 @@ func registerTCPFlags(tf *tinyflags.FlagSet) {
 		Validate(validateOptionalMaxAttempts).
 		Placeholder("N")
 	registerRetryFlags(tcp)
+	registerLatencyFlags(tcp)
+	registerResolveFlags(tcp)
+	registerResolveOverrideFlags(tcp)
+	registerSourceFlags(tcp)
 }
@@ -30,3 +30,144 @@ func TestParseFlagsTCPTarget(t *testing.T) {
 	assert.Equal(t, 3*time.Second, target.TCPTimeout)
 	assert.Equal(t, 4*time.Second, target.Interval)
 }
+
+// TestParseFlagsLatencyThreshold verifies latency flags are parsed, defaulted and validated.
+func TestParseFlagsLatencyThreshold(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{"--tcp.db.address=example.com:5432"}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+
+		target := parsedFlags.Targets[0]
+		assert.Zero(t, target.MaxLatency)
+		assert.Equal(t, 100, target.LatencyPercentile)
+		assert.Equal(t, 1, target.LatencyWindow)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{
+			"--tcp.db.address=example.com:5432",
+			"--tcp.db.max-latency=200ms",
+			"--tcp.db.latency-percentile=95",
+			"--tcp.db.latency-window=20",
+		}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+
+		target := parsedFlags.Targets[0]
+		assert.Equal(t, 200*time.Millisecond, target.MaxLatency)
+		assert.Equal(t, 95, target.LatencyPercentile)
+		assert.Equal(t, 20, target.LatencyWindow)
+	})
+
+	for _, arg := range []string{
+		"--tcp.db.max-latency=-1s",
+		"--tcp.db.latency-percentile=0",
+		"--tcp.db.latency-percentile=101",
+		"--tcp.db.latency-window=0",
+	} {
+		t.Run(arg, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseFlags([]string{"--tcp.db.address=example.com:5432", arg}, "1.0.0")
+			require.Error(t, err)
+		})
+	}
+}
+
+// TestParseFlagsResolveMode verifies resolve flags are parsed, defaulted and validated.
+func TestParseFlagsResolveMode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{"--tcp.db.address=example.com:5432"}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.Equal(t, checker.ResolveFirst, parsedFlags.Targets[0].Resolve)
+		assert.Equal(t, 1, parsedFlags.Targets[0].MinReadyAddresses)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{
+			"--tcp.db.address=example.com:5432",
+			"--tcp.db.resolve=any",
+			"--tcp.db.min-ready-addresses=2",
+			"--tcp.db.dns-server=10.96.0.10",
+			"--tcp.db.dns-timeout=500ms",
+		}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.Equal(t, checker.ResolveAny, parsedFlags.Targets[0].Resolve)
+		assert.Equal(t, 2, parsedFlags.Targets[0].MinReadyAddresses)
+		assert.Equal(t, "10.96.0.10", parsedFlags.Targets[0].DNSServer)
+		assert.Equal(t, 500*time.Millisecond, parsedFlags.Targets[0].DNSTimeout)
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{"--tcp.db.address=example.com:5432", "--tcp.db.resolve=some"}, "1.0.0")
+		assertInvalidFlagValueError(t, err, "--tcp.db.resolve", "some", checker.ResolveFirst.String(), checker.ResolveAny.String(), checker.ResolveAll.String())
+	})
+}
+
+// TestParseFlagsResolveOverride verifies resolve overrides are collected and validated.
+func TestParseFlagsResolveOverride(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{
+			"--tcp.db.address=db.example.com:5432",
+			"--tcp.db.resolve-override=db.example.com:5432:10.0.0.7",
+			"--tcp.db.resolve-override=db.example.com:5433:[2001:db8::7]",
+		}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.Equal(t, []string{"db.example.com:5432:10.0.0.7", "db.example.com:5433:[2001:db8::7]"}, parsedFlags.Targets[0].ResolveOverrides)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{"--tcp.db.address=db.example.com:5432", "--tcp.db.resolve-override=db.example.com:10.0.0.7"}, "1.0.0")
+		require.Error(t, err)
+	})
+}
+
+// TestParseFlagsSourceBinding verifies the source address and interface are parsed and validated.
+func TestParseFlagsSourceBinding(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		parsedFlags, err := ParseFlags([]string{
+			"--tcp.db.address=db.example.com:5432",
+			"--tcp.db.source-address=192.168.100.5",
+			"--tcp.db.interface=net1",
+		}, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, parsedFlags.Targets, 1)
+		assert.Equal(t, "192.168.100.5", parsedFlags.Targets[0].SourceAddress)
+		assert.Equal(t, "net1", parsedFlags.Targets[0].Interface)
+	})
+
+	t.Run("invalid source address", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFlags([]string{"--icmp.host.address=example.com", "--icmp.host.source-address=net1"}, "1.0.0")
+		require.Error(t, err)
+	})
+}
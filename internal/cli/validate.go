@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"github.com/containeroo/tinyflags"
+)
+
+// validateCommand is the subcommand that checks the configuration without running any checks.
+const validateCommand string = "validate"
+
+// OutputFormat defines the supported output formats of the validate subcommand.
+type OutputFormat string
+
+const (
+	OutputTable OutputFormat = "table" // OutputTable prints the effective targets as an aligned table.
+	OutputJSON  OutputFormat = "json"  // OutputJSON prints the effective targets as JSON.
+)
+
+// registerValidateFlags registers flags of the validate subcommand and binds them to cfg.
+func registerValidateFlags(tf *tinyflags.FlagSet, cfg *Config) {
+	tinyflags.EnumVar(
+		tf,
+		&cfg.Output,
+		"output",
+		OutputTable,
+		"Output format of \"never validate\"",
+		OutputTable,
+		OutputJSON,
+	).Value()
+}
+
+// splitSubcommand strips a leading validate subcommand from args.
+func splitSubcommand(args []string) ([]string, bool) {
+	if len(args) > 0 && args[0] == validateCommand {
+		return args[1:], true
+	}
+
+	return args, false
+}
@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/containeroo/httputils"
+	"github.com/containeroo/never/internal/checker"
 	"github.com/containeroo/never/internal/utils"
 )
 
@@ -30,6 +33,15 @@ func validateHTTPAddress(s string) error {
 	return nil
 }
 
+// validateOptionalHTTPAddress validates an optional HTTP URL or resolvable value.
+func validateOptionalHTTPAddress(s string) error {
+	if s == "" {
+		return nil
+	}
+
+	return validateHTTPAddress(s)
+}
+
 // validateICMPAddress validates ICMP target hostnames, IPs, and resolvable values.
 func validateICMPAddress(s string) error {
 	s = strings.TrimSpace(s)
@@ -81,6 +93,115 @@ func validateHTTPStatusCodes(codes string) error {
 	return nil
 }
 
+// validateResolveOverride validates a host:port:ip resolve override.
+func validateResolveOverride(s string) error {
+	_, err := checker.ParseResolveOverride(s)
+	return err
+}
+
+// validateDNSServer validates an optional DNS server given as IP or IP:PORT.
+func validateDNSServer(s string) error {
+	if s == "" || net.ParseIP(s) != nil {
+		return nil
+	}
+
+	host, port, err := net.SplitHostPort(s)
+	if err != nil || net.ParseIP(host) == nil {
+		return fmt.Errorf("DNS server must be an IP or IP:PORT, got %q", s)
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("invalid DNS server port %q", port)
+	}
+
+	return nil
+}
+
+// validateSourceAddress validates an optional local IP.
+func validateSourceAddress(s string) error {
+	if s == "" || net.ParseIP(s) != nil {
+		return nil
+	}
+
+	return fmt.Errorf("source address must be an IP, got %q", s)
+}
+
+// validateProxyURL returns a validator for optional proxy URLs with one of schemes and resolvable values.
+func validateProxyURL(schemes []string) func(string) error {
+	return func(s string) error {
+		s = strings.TrimSpace(s)
+		if s == "" || utils.IsResolvableValue(s) {
+			return nil
+		}
+
+		u, err := url.Parse(s)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("invalid proxy URL: %q", s)
+		}
+		if !slices.Contains(schemes, u.Scheme) {
+			return fmt.Errorf("unsupported proxy scheme %q: must be one of %v", u.Scheme, schemes)
+		}
+
+		return nil
+	}
+}
+
+// validateListenAddress validates a host:port listen address where the host may be empty.
+func validateListenAddress(s string) error {
+	if _, _, err := net.SplitHostPort(s); err != nil {
+		return fmt.Errorf("listen address must be [host]:port (e.g. :8080): %w", err)
+	}
+
+	return nil
+}
+
+// validateOptionalListenAddress validates a listen address where empty disables the listener.
+func validateOptionalListenAddress(s string) error {
+	if s == "" {
+		return nil
+	}
+
+	return validateListenAddress(s)
+}
+
+// validateOptionalURL validates an optional http or https URL.
+func validateOptionalURL(s string) error {
+	if s == "" {
+		return nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid URL: %q", s)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme: %q", u.Scheme)
+	}
+
+	return nil
+}
+
+// validatePositiveInt returns a validator that rejects zero and negative values.
+func validatePositiveInt(name string) func(int) error {
+	return func(v int) error {
+		if v <= 0 {
+			return fmt.Errorf("%s must be positive", name)
+		}
+
+		return nil
+	}
+}
+
+// validateIntRange returns a validator that rejects values outside [lo, hi].
+func validateIntRange(name string, lo, hi int) func(int) error {
+	return func(v int) error {
+		if v < lo || v > hi {
+			return fmt.Errorf("%s must be between %d and %d", name, lo, hi)
+		}
+
+		return nil
+	}
+}
+
 // validateMaxAttempts validates the global max-attempts flag.
 func validateMaxAttempts(v int) error {
 	if v == 0 {
@@ -123,3 +244,19 @@ func validateNonNegativeDuration(name string) func(time.Duration) error {
 		return nil
 	}
 }
+
+// parsePercent parses a percentage such as "20%" or "20" between 0 and 100.
+func parsePercent(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil || v < 0 || v > 100 {
+		return 0, fmt.Errorf("invalid percentage %q: must be between 0%% and 100%%", s)
+	}
+
+	return v, nil
+}
+
+// validatePercent validates a percentage such as "20%".
+func validatePercent(s string) error {
+	_, err := parsePercent(s)
+	return err
+}
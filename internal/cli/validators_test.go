@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -274,6 +275,64 @@ func TestValidateNonNegativeDuration(t *testing.T) {
 	})
 }
 
+// TestValidateIntRange verifies values are accepted within the inclusive bounds only.
+func TestValidateIntRange(t *testing.T) {
+	t.Parallel()
+
+	validateTTL := validateIntRange("ttl", 0, 255)
+
+	t.Run("bounds", func(t *testing.T) {
+		t.Parallel()
+		assertNoValidationError(t, validateTTL(0))
+		assertNoValidationError(t, validateTTL(255))
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		t.Parallel()
+		assertExactValidationError(t, validateTTL(256), "ttl must be between 0 and 255")
+		assertExactValidationError(t, validateTTL(-1), "ttl must be between 0 and 255")
+	})
+}
+
+// TestValidateDNSServer verifies DNS servers are accepted as IP or IP:PORT only.
+func TestValidateDNSServer(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"", "10.96.0.10", "10.96.0.10:5353", "2001:db8::53", "[2001:db8::53]:53"} {
+		assertNoValidationError(t, validateDNSServer(input))
+	}
+
+	assertExactValidationError(t, validateDNSServer("dns.example.com"), `DNS server must be an IP or IP:PORT, got "dns.example.com"`)
+	assertExactValidationError(t, validateDNSServer("dns.example.com:53"), `DNS server must be an IP or IP:PORT, got "dns.example.com:53"`)
+	assertExactValidationError(t, validateDNSServer("10.96.0.10:dns"), `invalid DNS server port "dns"`)
+}
+
+// TestValidateSourceAddress verifies source addresses are accepted as IPs only.
+func TestValidateSourceAddress(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"", "192.168.100.5", "2001:db8::5"} {
+		assertNoValidationError(t, validateSourceAddress(input))
+	}
+
+	assertExactValidationError(t, validateSourceAddress("net1"), `source address must be an IP, got "net1"`)
+}
+
+// TestParsePercent verifies percentages with and without a percent sign.
+func TestParsePercent(t *testing.T) {
+	t.Parallel()
+
+	for input, want := range map[string]float64{"0%": 0, "20%": 20, "12.5": 12.5, " 100% ": 100} {
+		got, err := parsePercent(input)
+		require.NoError(t, err, input)
+		assert.InDelta(t, want, got, 0.001, input)
+	}
+
+	for _, input := range []string{"", "%", "-1%", "101%", "abc"} {
+		assertExactValidationError(t, validatePercent(input), fmt.Sprintf("invalid percentage %q: must be between 0%% and 100%%", input))
+	}
+}
+
 // assertNoValidationError verifies a validator accepted the value.
 func assertNoValidationError(t *testing.T, err error) {
 	t.Helper()
@@ -0,0 +1,42 @@
+package exitcode
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Exit codes returned by never.
+const (
+	OK           int = 0 // OK means all targets became ready.
+	Failure      int = 1 // Failure is any error without a more specific code.
+	Config       int = 2 // Config means the flags, environment variables or config file are invalid.
+	NotReady     int = 3 // NotReady means a target exceeded its max attempts, failed permanently or failed its --once check.
+	Deadline     int = 4 // Deadline means a deadline expired before all targets were ready.
+	Interrupted  int = 5 // Interrupted means a signal stopped never before all targets were ready.
+	CheckerSetup int = 6 // CheckerSetup means a checker could not be created, e.g. for an ICMP address outside its ip-family.
+)
+
+// descriptions documents the exit codes in ascending order.
+var descriptions = []struct {
+	code int
+	desc string
+}{
+	{OK, "all targets are ready"},
+	{Failure, "unexpected error, e.g. the command after \"--\" could not be executed"},
+	{Config, "invalid flags, environment variables or config file"},
+	{NotReady, "a target exceeded its max attempts, failed permanently or failed its --once check"},
+	{Deadline, "a deadline expired before all targets were ready"},
+	{Interrupted, "a signal stopped never before all targets were ready"},
+	{CheckerSetup, "a checker could not be created, e.g. for an ICMP address outside its ip-family"},
+}
+
+// Help returns the documented exit codes, one per line.
+func Help() string {
+	var b strings.Builder
+	b.WriteString("Exit codes:")
+	for _, d := range descriptions {
+		fmt.Fprintf(&b, "\n%d: %s", d.code, d.desc)
+	}
+
+	return b.String()
+}
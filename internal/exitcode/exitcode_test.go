@@ -0,0 +1,22 @@
+package exitcode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHelp verifies every exit code is documented in ascending order.
+func TestHelp(t *testing.T) {
+	t.Parallel()
+
+	want := "Exit codes:\n" +
+		"0: all targets are ready\n" +
+		"1: unexpected error, e.g. the command after \"--\" could not be executed\n" +
+		"2: invalid flags, environment variables or config file\n" +
+		"3: a target exceeded its max attempts, failed permanently or failed its --once check\n" +
+		"4: a deadline expired before all targets were ready\n" +
+		"5: a signal stopped never before all targets were ready\n" +
+		"6: a checker could not be created, e.g. for an ICMP address outside its ip-family"
+	assert.Equal(t, want, Help())
+}
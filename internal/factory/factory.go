@@ -1,8 +1,11 @@
 package factory
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -12,6 +15,7 @@ import (
 
 	"github.com/containeroo/httputils"
 	"github.com/containeroo/resolver"
+	"golang.org/x/net/http/httpproxy"
 )
 
 const (
@@ -30,11 +34,36 @@ type TargetConfig struct {
 	Backoff     backoff.Mode
 	MaxInterval time.Duration
 
+	MaxLatency        time.Duration
+	LatencyPercentile int
+	LatencyWindow     int
+
+	Resolve           checker.ResolveMode
+	MinReadyAddresses int
+	ResolveOverrides  []string
+	DNSServer         string
+	DNSTimeout        time.Duration
+
+	SourceAddress string
+	Interface     string
+
+	Proxy string
+
 	HTTPMethod                string
 	HTTPHeaders               []string
 	HTTPAllowDuplicateHeaders bool
 	HTTPExpectedStatusCodes   []string
+	HTTPFailFastStatusCodes   []string
+	HTTPFailFastTLS           bool
 	HTTPSkipTLSVerify         bool
+	HTTPFollowRedirects       bool
+	HTTPMaxRedirects          int
+	HTTPExpectedFinalURL      string
+	HTTPNoProxy               bool
+	HTTPOAuth2TokenURL        string
+	HTTPOAuth2ClientID        string
+	HTTPOAuth2ClientSecret    string
+	HTTPOAuth2Scopes          []string
 	HTTPTimeout               time.Duration
 
 	TCPTimeout time.Duration
@@ -42,8 +71,30 @@ type TargetConfig struct {
 	ICMPTimeout      time.Duration
 	ICMPReadTimeout  time.Duration
 	ICMPWriteTimeout time.Duration
+	ICMPIPFamily     checker.IPFamily
+	ICMPPrivileged   checker.ICMPPrivileged
+	ICMPCount        int
+	ICMPMaxLoss      float64 // ICMPMaxLoss is the highest tolerated packet loss in percent.
+	ICMPMaxRTT       time.Duration
+	ICMPSize         int // ICMPSize is the echo payload size in bytes.
+	ICMPTTL          int
+	ICMPDontFragment bool
 }
 
+// CheckerError is returned by BuildCheckers when a checker cannot be created from its target configuration.
+type CheckerError struct {
+	Type checker.CheckType
+	Err  error
+}
+
+// Error implements error.
+func (e *CheckerError) Error() string {
+	return fmt.Sprintf("failed to create %s checker: %v", e.Type, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *CheckerError) Unwrap() error { return e.Err }
+
 // CheckerWithInterval represents a checker with its interval.
 type CheckerWithInterval struct {
 	Interval time.Duration
@@ -52,6 +103,8 @@ type CheckerWithInterval struct {
 	MaxAttempts int
 	Backoff     backoff.Mode
 	MaxInterval time.Duration
+	// Warnings describe settings of the target that are valid but do not take effect.
+	Warnings []string
 }
 
 // BuildCheckers creates a list of CheckerWithInterval from typed target configuration.
@@ -70,6 +123,54 @@ func BuildCheckers(targets []TargetConfig, defaultInterval time.Duration, versio
 
 		var opts []checker.Option
 
+		if target.MinReadyAddresses > 1 && target.Resolve != checker.ResolveAny {
+			return nil, fmt.Errorf("%s requires %s=%s", flagName(target, "min-ready-addresses"), flagName(target, "resolve"), checker.ResolveAny)
+		}
+		if target.Resolve == checker.ResolveAny || target.Resolve == checker.ResolveAll {
+			opts = append(opts, checker.WithResolveMode(target.Resolve, target.MinReadyAddresses))
+		}
+
+		if target.DNSServer != "" || target.DNSTimeout > 0 {
+			opts = append(opts, checker.WithDNSResolver(target.DNSServer, target.DNSTimeout))
+		}
+
+		if len(target.ResolveOverrides) > 0 {
+			if target.Resolve == checker.ResolveAny || target.Resolve == checker.ResolveAll {
+				return nil, fmt.Errorf("%s cannot be combined with %s=%s", flagName(target, "resolve-override"), flagName(target, "resolve"), target.Resolve)
+			}
+			overrides := make([]checker.ResolveOverride, 0, len(target.ResolveOverrides))
+			for _, value := range target.ResolveOverrides {
+				override, err := checker.ParseResolveOverride(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %q: %w", flagName(target, "resolve-override"), err)
+				}
+				overrides = append(overrides, override)
+			}
+			opts = append(opts, checker.WithResolveOverrides(overrides...))
+		}
+
+		if target.SourceAddress != "" {
+			ip := net.ParseIP(target.SourceAddress)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid %q: %q is not an IP", flagName(target, "source-address"), target.SourceAddress)
+			}
+			opts = append(opts, checker.WithSourceAddress(ip))
+		}
+		if target.Interface != "" {
+			opts = append(opts, checker.WithInterface(target.Interface))
+		}
+
+		if target.Proxy != "" {
+			if conflict := proxyConflict(target); conflict != "" {
+				return nil, fmt.Errorf("%s cannot be combined with %s", flagName(target, "proxy"), flagName(target, conflict))
+			}
+			proxyURL, err := parseProxyURL(target.Proxy)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %q: %w", flagName(target, "proxy"), err)
+			}
+			opts = append(opts, checker.WithProxy(proxyURL))
+		}
+
 		switch target.Type {
 		case checker.HTTP:
 			if target.HTTPMethod != "" {
@@ -78,7 +179,7 @@ func BuildCheckers(targets []TargetConfig, defaultInterval time.Duration, versio
 
 			headersMap, err := createHTTPHeadersMap(target.HTTPHeaders, target.HTTPAllowDuplicateHeaders)
 			if err != nil {
-				return nil, fmt.Errorf("invalid \"--%s.%s.header\": %w", strings.ToLower(target.Type.String()), target.ID, err)
+				return nil, fmt.Errorf("invalid %q: %w", flagName(target, "header"), err)
 			}
 			setDefaultUserAgent(headersMap, version)
 			opts = append(opts, checker.WithHTTPHeaders(headersMap))
@@ -88,12 +189,42 @@ func BuildCheckers(targets []TargetConfig, defaultInterval time.Duration, versio
 				// Get all status codes as a slice. Can produce something like []string{"200-299", "300", "301"}.
 				codes, err := httputils.ParseStatusCodes(strings.Join(target.HTTPExpectedStatusCodes, ","))
 				if err != nil {
-					return nil, fmt.Errorf("invalid --%s.%s.expected-status-codes: %w", strings.ToLower(target.Type.String()), target.ID, err)
+					return nil, fmt.Errorf("invalid %q: %w", flagName(target, "expected-status-codes"), err)
 				}
 				opts = append(opts, checker.WithExpectedStatusCodes(codes))
 			}
 
+			if len(target.HTTPFailFastStatusCodes) > 0 {
+				codes, err := httputils.ParseStatusCodes(strings.Join(target.HTTPFailFastStatusCodes, ","))
+				if err != nil {
+					return nil, fmt.Errorf("invalid %q: %w", flagName(target, "fail-fast-status-codes"), err)
+				}
+				opts = append(opts, checker.WithFailFastStatusCodes(codes))
+			}
+
+			opts = append(opts, checker.WithHTTPFailFastTLS(target.HTTPFailFastTLS))
 			opts = append(opts, checker.WithHTTPSkipTLSVerify(target.HTTPSkipTLSVerify))
+			opts = append(opts, checker.WithHTTPFollowRedirects(target.HTTPFollowRedirects))
+
+			if target.HTTPNoProxy {
+				opts = append(opts, checker.WithHTTPNoProxy(true))
+			}
+
+			if target.HTTPOAuth2TokenURL != "" || target.HTTPOAuth2ClientID != "" || target.HTTPOAuth2ClientSecret != "" {
+				config, err := createOAuth2Config(target)
+				if err != nil {
+					return nil, err
+				}
+				opts = append(opts, checker.WithHTTPOAuth2(config))
+			}
+
+			if target.HTTPMaxRedirects > 0 {
+				opts = append(opts, checker.WithHTTPMaxRedirects(target.HTTPMaxRedirects))
+			}
+
+			if target.HTTPExpectedFinalURL != "" {
+				opts = append(opts, checker.WithHTTPExpectedFinalURL(target.HTTPExpectedFinalURL))
+			}
 
 			if target.HTTPTimeout > 0 {
 				opts = append(opts, checker.WithHTTPTimeout(target.HTTPTimeout))
@@ -117,13 +248,45 @@ func BuildCheckers(targets []TargetConfig, defaultInterval time.Duration, versio
 				opts = append(opts, checker.WithICMPWriteTimeout(target.ICMPWriteTimeout))
 			}
 
+			if target.ICMPIPFamily != "" {
+				opts = append(opts, checker.WithICMPIPFamily(target.ICMPIPFamily))
+			}
+
+			if target.ICMPPrivileged != "" {
+				opts = append(opts, checker.WithICMPPrivileged(target.ICMPPrivileged))
+			}
+
+			if target.ICMPCount > 0 {
+				opts = append(opts, checker.WithICMPCount(target.ICMPCount))
+			}
+
+			opts = append(opts, checker.WithICMPMaxLoss(target.ICMPMaxLoss), checker.WithICMPMaxRTT(target.ICMPMaxRTT))
+
+			if target.ICMPSize > 0 {
+				opts = append(opts, checker.WithICMPSize(target.ICMPSize))
+			}
+
+			if target.ICMPTTL > 0 {
+				opts = append(opts, checker.WithICMPTTL(target.ICMPTTL))
+			}
+
+			opts = append(opts, checker.WithICMPDontFragment(target.ICMPDontFragment))
+
 		default:
 			return nil, fmt.Errorf("unsupported check type: %s", target.Type)
 		}
 
 		instance, err := checker.NewChecker(target.Type, name, resolvedAddr, opts...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create %s checker: %w", target.Type, err)
+			return nil, &CheckerError{Type: target.Type, Err: err}
+		}
+		if target.MaxLatency > 0 {
+			instance = checker.NewLatencyChecker(instance, target.MaxLatency, target.LatencyPercentile, target.LatencyWindow)
+		}
+
+		var warnings []string
+		if warning := environmentProxyWarning(target, resolvedAddr); warning != "" {
+			warnings = append(warnings, warning)
 		}
 
 		checkers = append(checkers, CheckerWithInterval{
@@ -132,12 +295,18 @@ func BuildCheckers(targets []TargetConfig, defaultInterval time.Duration, versio
 			MaxAttempts: target.MaxAttempts,
 			Backoff:     backoffMode,
 			MaxInterval: target.MaxInterval,
+			Warnings:    warnings,
 		})
 	}
 
 	return checkers, nil
 }
 
+// flagName returns the command-line flag of a target property, for example "--http.web.header".
+func flagName(target TargetConfig, prop string) string {
+	return fmt.Sprintf("--%s.%s.%s", strings.ToLower(target.Type.String()), target.ID, prop)
+}
+
 // setDefaultUserAgent adds the application user agent unless the target already configured one.
 func setDefaultUserAgent(headers http.Header, version string) {
 	if headers.Get(userAgentHeader) != "" {
@@ -147,6 +316,114 @@ func setDefaultUserAgent(headers http.Header, version string) {
 	headers.Set(userAgentHeader, userAgentPrefix+version)
 }
 
+// proxyConflict returns the flag that cannot be combined with a proxy, or an empty string.
+// The proxy resolves the hostname, so flags that control resolution would have no effect.
+func proxyConflict(target TargetConfig) string {
+	switch {
+	case target.HTTPNoProxy:
+		return "no-proxy"
+	case target.DNSServer != "":
+		return "dns-server"
+	case target.DNSTimeout > 0:
+		return "dns-timeout"
+	case target.Type != checker.HTTP:
+		return ""
+	case len(target.ResolveOverrides) > 0:
+		return "resolve-override"
+	case target.Resolve == checker.ResolveAny || target.Resolve == checker.ResolveAll:
+		return "resolve=" + target.Resolve.String()
+	}
+
+	return ""
+}
+
+// environmentProxyWarning returns a warning if the proxy from the environment applies to an HTTP target
+// but conflicts with its resolution flags, or an empty string.
+func environmentProxyWarning(target TargetConfig, address string) string {
+	if target.Type != checker.HTTP || target.Proxy != "" || target.HTTPNoProxy {
+		return ""
+	}
+	conflict := proxyConflict(target)
+	if conflict == "" {
+		return ""
+	}
+
+	targetURL, err := url.Parse(address)
+	if err != nil {
+		return ""
+	}
+	if conflict == "resolve-override" && !matchesResolveOverride(target.ResolveOverrides, targetURL) {
+		// Only overridden hosts bypass the proxy.
+		return ""
+	}
+	if proxyURL, err := httpproxy.FromEnvironment().ProxyFunc()(targetURL); err != nil || proxyURL == nil {
+		return ""
+	}
+
+	if conflict == "dns-timeout" {
+		return fmt.Sprintf("%s has no effect, the proxy from the environment resolves the hostname", flagName(target, conflict))
+	}
+
+	return fmt.Sprintf("the proxy from the environment is not used because %s is set", flagName(target, conflict))
+}
+
+// matchesResolveOverride reports whether one of the resolve overrides applies to targetURL.
+func matchesResolveOverride(values []string, targetURL *url.URL) bool {
+	address := checker.CanonicalAddress(targetURL)
+	for _, value := range values {
+		if override, err := checker.ParseResolveOverride(value); err == nil && override.Matches(address) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseProxyURL resolves variables in a proxy URL and parses it.
+// Errors do not repeat the URL, which may contain credentials.
+func parseProxyURL(value string) (*url.URL, error) {
+	resolved, err := resolver.ResolveVariable(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve variable: %w", err)
+	}
+
+	proxyURL, err := url.Parse(strings.TrimSpace(resolved))
+	if err != nil || proxyURL.Host == "" {
+		return nil, errors.New("proxy must be a URL such as socks5://host:1080")
+	}
+
+	return proxyURL, nil
+}
+
+// createOAuth2Config resolves variables in the OAuth2 flags of target and checks they are complete.
+func createOAuth2Config(target TargetConfig) (checker.OAuth2Config, error) {
+	if target.HTTPOAuth2TokenURL == "" || target.HTTPOAuth2ClientID == "" {
+		return checker.OAuth2Config{}, fmt.Errorf("%s and %s are required for OAuth2", flagName(target, "oauth2-token-url"), flagName(target, "oauth2-client-id"))
+	}
+
+	resolve := func(prop, value string) (string, error) {
+		resolved, err := resolver.ResolveVariable(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid %q: failed to resolve variable: %w", flagName(target, prop), err)
+		}
+		return resolved, nil
+	}
+
+	config := checker.OAuth2Config{Scopes: target.HTTPOAuth2Scopes}
+	var err error
+	if config.TokenURL, err = resolve("oauth2-token-url", target.HTTPOAuth2TokenURL); err != nil {
+		return checker.OAuth2Config{}, err
+	}
+	if config.ClientID, err = resolve("oauth2-client-id", target.HTTPOAuth2ClientID); err != nil {
+		return checker.OAuth2Config{}, err
+	}
+	if config.ClientSecret, err = resolve("oauth2-client-secret", target.HTTPOAuth2ClientSecret); err != nil {
+		return checker.OAuth2Config{}, err
+	}
+
+	return config, nil
+}
+
 // createHTTPHeadersMap creates an http.Header from a slice of key=value strings.
 // If allowDuplicateHeaders is true, headers with the same key will be appended.
 func createHTTPHeadersMap(headers []string, allowDuplicateHeaders bool) (http.Header, error) {
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/containeroo/never/internal/checker"
+)
+
+// instrumentedChecker records every check of the wrapped Checker in a Registry.
+type instrumentedChecker struct {
+	checker.Checker
+	registry *Registry
+}
+
+// Instrument wraps c so the attempts, latency and failures of every check are recorded.
+// It returns c unchanged on a nil Registry.
+func (r *Registry) Instrument(c checker.Checker) checker.Checker {
+	if r == nil {
+		return c
+	}
+
+	return &instrumentedChecker{Checker: c, registry: r}
+}
+
+// Unwrap returns the wrapped checker.
+func (c *instrumentedChecker) Unwrap() checker.Checker { return c.Checker }
+
+// Check runs the wrapped check and records its duration and result.
+func (c *instrumentedChecker) Check(ctx context.Context) error {
+	start := time.Now()
+	err := c.Checker.Check(ctx)
+	c.registry.observeCheck(c.Name(), c.Type(), time.Since(start), err)
+
+	return err
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/containeroo/never/internal/checker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubChecker is a Checker returning a fixed error.
+type stubChecker struct{ err error }
+
+func (s stubChecker) Check(context.Context) error { return s.err }
+func (s stubChecker) Name() string                { return "db" }
+func (s stubChecker) Type() string                { return "TCP" }
+func (s stubChecker) Address() string             { return "127.0.0.1:5432" }
+
+// TestInstrument verifies every check of an instrumented checker is recorded.
+func TestInstrument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("records checks", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewRegistry()
+		want := &checker.UnexpectedStatusCodeError{StatusCode: 503}
+		c := r.Instrument(stubChecker{err: want})
+
+		require.ErrorIs(t, c.Check(context.Background()), want)
+		require.ErrorIs(t, c.Check(context.Background()), want)
+		assert.Equal(t, "db", c.Name())
+		assert.Equal(t, stubChecker{err: want}, c.(interface{ Unwrap() checker.Checker }).Unwrap())
+
+		text := scrape(t, r)
+		assert.Contains(t, text, `never_check_attempts_total{target="db",type="TCP"} 2`)
+		assert.Contains(t, text, `never_check_failures_total{reason="status_code",target="db",type="TCP"} 2`)
+		assert.Contains(t, text, `never_check_duration_seconds_count{target="db",type="TCP"} 2`)
+	})
+
+	t.Run("nil registry", func(t *testing.T) {
+		t.Parallel()
+
+		var r *Registry
+		c := stubChecker{err: errors.New("boom")}
+
+		assert.Equal(t, c, r.Instrument(c))
+	})
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"syscall"
+
+	"github.com/containeroo/never/internal/checker"
+)
+
+const (
+	ReasonTimeout           string = "timeout"
+	ReasonCanceled          string = "canceled"
+	ReasonConnectionRefused string = "connection_refused"
+	ReasonDNS               string = "dns"
+	ReasonTLS               string = "tls"
+	ReasonStatusCode        string = "status_code"
+	ReasonLatency           string = "latency"
+	ReasonUnreachable       string = "unreachable"
+	ReasonOAuth2Token       string = "oauth2_token"
+	ReasonOther             string = "other"
+)
+
+// ErrorClass maps a check error to a low-cardinality reason label.
+func ErrorClass(err error) string {
+	var (
+		dnsErr       *net.DNSError
+		statusErr    *checker.UnexpectedStatusCodeError
+		latencyErr   *checker.LatencyError
+		icmpMsgErr   *checker.ICMPMessageError
+		tokenErr     *checker.TokenError
+		netErr       net.Error
+		certErr      *tls.CertificateVerificationError
+		unknownCAErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		certInvalid  x509.CertificateInvalidError
+		recordHdrErr tls.RecordHeaderError
+	)
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ReasonCanceled
+	case errors.As(err, &tokenErr):
+		// Token endpoint failures are not failures of the target.
+		return ReasonOAuth2Token
+	case errors.Is(err, context.DeadlineExceeded):
+		return ReasonTimeout
+	case errors.As(err, &statusErr):
+		return ReasonStatusCode
+	case errors.As(err, &latencyErr):
+		return ReasonLatency
+	case errors.As(err, &icmpMsgErr):
+		return ReasonUnreachable
+	case errors.As(err, &dnsErr):
+		return ReasonDNS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return ReasonConnectionRefused
+	case errors.As(err, &certErr),
+		errors.As(err, &unknownCAErr),
+		errors.As(err, &hostnameErr),
+		errors.As(err, &certInvalid),
+		errors.As(err, &recordHdrErr):
+		return ReasonTLS
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ReasonTimeout
+	default:
+		return ReasonOther
+	}
+}
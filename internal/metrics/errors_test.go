@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/containeroo/never/internal/checker"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestErrorClass verifies errors are mapped to their reason labels.
+func TestErrorClass(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "deadline", err: fmt.Errorf("wrapped: %w", context.DeadlineExceeded), want: ReasonTimeout},
+		{name: "canceled", err: context.Canceled, want: ReasonCanceled},
+		{name: "status", err: &checker.UnexpectedStatusCodeError{StatusCode: 503}, want: ReasonStatusCode},
+		{name: "latency", err: &checker.LatencyError{Latency: 2, MaxLatency: 1}, want: ReasonLatency},
+		{name: "unreachable", err: &checker.ICMPMessageError{Err: checker.ErrHostUnreachable}, want: ReasonUnreachable},
+		{name: "oauth2 token", err: &checker.TokenError{TokenURL: "https://idp", Err: context.DeadlineExceeded}, want: ReasonOAuth2Token},
+		{name: "dns", err: &net.DNSError{Err: "no such host", Name: "x"}, want: ReasonDNS},
+		{name: "refused", err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, want: ReasonConnectionRefused},
+		{
+			name: "multi-address refused",
+			err: &checker.MultiAddressError{Host: "db", Required: 1, Results: []checker.AddressResult{
+				{IP: net.ParseIP("10.0.0.1"), Err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}},
+			}},
+			want: ReasonConnectionRefused,
+		},
+		{name: "other", err: errors.New("boom"), want: ReasonOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ErrorClass(tt.err))
+		})
+	}
+}
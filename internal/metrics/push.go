@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/push"
+	"github.com/prometheus/common/expfmt"
+)
+
+const pushTimeout time.Duration = 10 * time.Second
+
+// Push sends all metrics to a Pushgateway-compatible endpoint, replacing the metrics of job.
+func (r *Registry) Push(ctx context.Context, gatewayURL, job string) error {
+	if r == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pushTimeout)
+	defer cancel()
+
+	// The text format is accepted by every Pushgateway-compatible endpoint.
+	pusher := push.New(gatewayURL, job).
+		Gatherer(r.registry).
+		Format(expfmt.NewFormat(expfmt.TypeTextPlain))
+	if err := pusher.PushContext(ctx); err != nil {
+		return fmt.Errorf("failed to push metrics: %w", err)
+	}
+
+	return nil
+}
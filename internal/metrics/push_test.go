@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegistryPush verifies metrics are sent to the Pushgateway job endpoint.
+func TestRegistryPush(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		method string
+		path   string
+		body   string
+	}
+	received := make(chan request, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- request{method: r.Method, path: r.URL.Path, body: string(body)}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	r := NewRegistry()
+	r.SetReady("db", "TCP", true)
+
+	require.NoError(t, r.Push(context.Background(), server.URL+"/", "never"))
+
+	req := <-received
+	assert.Equal(t, http.MethodPut, req.method)
+	assert.Equal(t, "/metrics/job/never", req.path)
+	assert.Contains(t, req.body, `never_target_ready{target="db",type="TCP"} 1`)
+}
+
+// TestRegistryPushError verifies non-2xx responses are reported.
+func TestRegistryPushError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	err := NewRegistry().Push(context.Background(), server.URL, "never")
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "failed to push metrics: unexpected status code 400")
+}
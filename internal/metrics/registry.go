@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// defaultBuckets are the latency histogram upper bounds in seconds.
+var defaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Registry collects check metrics in a dedicated Prometheus registry.
+// All methods are safe for concurrent use and are no-ops on a nil Registry.
+type Registry struct {
+	registry *prometheus.Registry
+
+	attempts     *prometheus.CounterVec
+	failures     *prometheus.CounterVec
+	latency      *prometheus.HistogramVec
+	timeToReady  *prometheus.GaugeVec
+	ready        *prometheus.GaugeVec
+	nextInterval *prometheus.GaugeVec
+}
+
+// NewRegistry creates a Registry with all never metrics registered.
+func NewRegistry() *Registry {
+	r := &Registry{
+		registry: prometheus.NewRegistry(),
+		attempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "never_check_attempts_total",
+			Help: "Total number of check attempts.",
+		}, []string{"target", "type"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "never_check_failures_total",
+			Help: "Total number of failed check attempts by error class.",
+		}, []string{"target", "type", "reason"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "never_check_duration_seconds",
+			Help:    "Duration of check attempts in seconds.",
+			Buckets: defaultBuckets,
+		}, []string{"target", "type"}),
+		timeToReady: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "never_target_time_to_ready_seconds",
+			Help: "Time in seconds from the first attempt until the target became ready.",
+		}, []string{"target", "type"}),
+		ready: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "never_target_ready",
+			Help: "Whether the target is currently ready (1) or not (0).",
+		}, []string{"target", "type"}),
+		nextInterval: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "never_check_next_interval_seconds",
+			Help: "Delay in seconds before the next check attempt.",
+		}, []string{"target", "type"}),
+	}
+	r.registry.MustRegister(r.attempts, r.failures, r.latency, r.timeToReady, r.ready, r.nextInterval)
+
+	return r
+}
+
+// observeCheck records one check attempt with its duration and result.
+func (r *Registry) observeCheck(target, checkType string, d time.Duration, err error) {
+	if r == nil {
+		return
+	}
+
+	r.attempts.WithLabelValues(target, checkType).Inc()
+	r.latency.WithLabelValues(target, checkType).Observe(d.Seconds())
+	if err != nil {
+		r.failures.WithLabelValues(target, checkType, ErrorClass(err)).Inc()
+	}
+}
+
+// SetReady records the current readiness of a target.
+func (r *Registry) SetReady(target, checkType string, ready bool) {
+	if r == nil {
+		return
+	}
+
+	v := 0.0
+	if ready {
+		v = 1
+	}
+	r.ready.WithLabelValues(target, checkType).Set(v)
+}
+
+// SetTimeToReady records how long a target took to become ready.
+func (r *Registry) SetTimeToReady(target, checkType string, d time.Duration) {
+	if r == nil {
+		return
+	}
+
+	r.timeToReady.WithLabelValues(target, checkType).Set(d.Seconds())
+}
+
+// SetNextInterval records the delay before the next attempt of a target.
+func (r *Registry) SetNextInterval(target, checkType string, d time.Duration) {
+	if r == nil {
+		return
+	}
+
+	r.nextInterval.WithLabelValues(target, checkType).Set(d.Seconds())
+}
+
+// Handler returns an http.Handler serving the metrics.
+func (r *Registry) Handler() http.Handler {
+	if r == nil {
+		return promhttp.HandlerFor(prometheus.NewRegistry(), promhttp.HandlerOpts{})
+	}
+
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scrape returns the metrics served by the handler of r.
+func scrape(t *testing.T, r *Registry) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	return rec.Body.String()
+}
+
+// TestRegistryHandler verifies metrics are served in the Prometheus text format.
+func TestRegistryHandler(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+	r.observeCheck("db", "TCP", 20*time.Millisecond, errors.New("boom"))
+	r.observeCheck("db", "TCP", 300*time.Millisecond, nil)
+	r.SetReady("db", "TCP", true)
+	r.SetTimeToReady("db", "TCP", 1500*time.Millisecond)
+	r.SetNextInterval("db", "TCP", 2*time.Second)
+
+	text := scrape(t, r)
+	assert.Contains(t, text, "# TYPE never_check_attempts_total counter\n")
+	assert.Contains(t, text, `never_check_attempts_total{target="db",type="TCP"} 2`+"\n")
+	assert.Contains(t, text, `never_check_failures_total{reason="other",target="db",type="TCP"} 1`+"\n")
+	assert.Contains(t, text, "# TYPE never_check_duration_seconds histogram\n")
+	assert.Contains(t, text, `never_check_duration_seconds_bucket{target="db",type="TCP",le="0.025"} 1`+"\n")
+	assert.Contains(t, text, `never_check_duration_seconds_bucket{target="db",type="TCP",le="0.5"} 2`+"\n")
+	assert.Contains(t, text, `never_check_duration_seconds_bucket{target="db",type="TCP",le="+Inf"} 2`+"\n")
+	assert.Contains(t, text, `never_check_duration_seconds_count{target="db",type="TCP"} 2`+"\n")
+	assert.Contains(t, text, `never_target_ready{target="db",type="TCP"} 1`+"\n")
+	assert.Contains(t, text, `never_target_time_to_ready_seconds{target="db",type="TCP"} 1.5`+"\n")
+	assert.Contains(t, text, `never_check_next_interval_seconds{target="db",type="TCP"} 2`+"\n")
+}
+
+// TestRegistryEscapesLabels verifies label values are escaped.
+func TestRegistryEscapesLabels(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+	r.SetReady(`we"ird\name`, "HTTP", false)
+
+	assert.Contains(t, scrape(t, r), `never_target_ready{target="we\"ird\\name",type="HTTP"} 0`)
+}
+
+// TestRegistryNil verifies a nil registry is a no-op.
+func TestRegistryNil(t *testing.T) {
+	t.Parallel()
+
+	var r *Registry
+	r.observeCheck("db", "TCP", time.Second, nil)
+	r.SetReady("db", "TCP", true)
+
+	assert.NotContains(t, scrape(t, r), "never_")
+}
@@ -0,0 +1,57 @@
+package monitor
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// statusResponse is the JSON body served on /status.
+type statusResponse struct {
+	Ready   bool           `json:"ready"`
+	Targets []TargetStatus `json:"targets"`
+}
+
+// Handler returns an http.Handler serving /livez, /readyz and /status.
+func (m *Monitor) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /livez", m.handleLive)
+	mux.HandleFunc("GET /readyz", m.handleReady)
+	mux.HandleFunc("GET /status", m.handleStatus)
+	return mux
+}
+
+// handleLive reports that the process is alive.
+func (m *Monitor) handleLive(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// handleReady returns 200 when all targets are ready and 503 otherwise.
+func (m *Monitor) handleReady(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if !m.Ready() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("not ready\n"))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// handleStatus writes the per-target status as JSON.
+func (m *Monitor) handleStatus(w http.ResponseWriter, _ *http.Request) {
+	statuses := m.Statuses()
+
+	resp := statusResponse{Ready: true, Targets: statuses}
+	for _, s := range statuses {
+		if !s.Ready {
+			resp.Ready = false
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
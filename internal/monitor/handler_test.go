@@ -0,0 +1,46 @@
+package monitor
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/containeroo/never/internal/factory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHandler verifies the health endpoints reflect the monitored target state.
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	m := New([]factory.CheckerWithInterval{{Checker: &stubChecker{}}})
+	handler := m.Handler()
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, serve("/livez").Code)
+	assert.Equal(t, http.StatusServiceUnavailable, serve("/readyz").Code)
+
+	m.record(m.targets[0], nil, time.Now(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	assert.Equal(t, http.StatusOK, serve("/readyz").Code)
+
+	rec := serve("/status")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body statusResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.True(t, body.Ready)
+	require.Len(t, body.Targets, 1)
+	assert.Equal(t, "stub", body.Targets[0].Name)
+	assert.Equal(t, 1, body.Targets[0].Checks)
+}
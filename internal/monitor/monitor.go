@@ -0,0 +1,244 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/containeroo/never/internal/factory"
+	"github.com/containeroo/never/internal/metrics"
+	"github.com/containeroo/never/internal/runner"
+	"github.com/containeroo/never/internal/wait"
+)
+
+// TargetStatus is a point-in-time view of a monitored target.
+type TargetStatus struct {
+	Name                 string    `json:"name"`
+	Type                 string    `json:"type"`
+	Address              string    `json:"address"`
+	Ready                bool      `json:"ready"`
+	ConsecutiveSuccesses int       `json:"consecutiveSuccesses"`
+	ConsecutiveFailures  int       `json:"consecutiveFailures"`
+	Checks               int       `json:"checks"`
+	LastError            string    `json:"lastError,omitempty"`
+	LastCheck            time.Time `json:"lastCheck,omitzero"`
+	LastTransition       time.Time `json:"lastTransition,omitzero"`
+}
+
+// target holds the mutable state of one monitored checker.
+type target struct {
+	checker factory.CheckerWithInterval
+	status  TargetStatus
+	started time.Time
+}
+
+// Monitor keeps probing all targets and tracks their readiness.
+type Monitor struct {
+	mu               sync.RWMutex
+	targets          []*target
+	successThreshold int
+	failureThreshold int
+	metrics          *metrics.Registry
+}
+
+// Option configures a Monitor.
+type Option func(*Monitor)
+
+// WithSuccessThreshold sets the consecutive successes needed to mark a target ready.
+func WithSuccessThreshold(n int) Option {
+	return func(m *Monitor) {
+		if n > 0 {
+			m.successThreshold = n
+		}
+	}
+}
+
+// WithFailureThreshold sets the consecutive failures needed to mark a target not ready.
+func WithFailureThreshold(n int) Option {
+	return func(m *Monitor) {
+		if n > 0 {
+			m.failureThreshold = n
+		}
+	}
+}
+
+// WithMetrics records readiness, time to ready and the next interval in the given registry.
+func WithMetrics(registry *metrics.Registry) Option {
+	return func(m *Monitor) {
+		m.metrics = registry
+	}
+}
+
+// New creates a Monitor for the given checkers. All targets start as not ready.
+// Without threshold options, every single result changes the readiness.
+func New(checkers []factory.CheckerWithInterval, opts ...Option) *Monitor {
+	m := &Monitor{
+		targets:          make([]*target, 0, len(checkers)),
+		successThreshold: 1,
+		failureThreshold: 1,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	for _, chk := range checkers {
+		m.targets = append(m.targets, &target{
+			checker: chk,
+			status: TargetStatus{
+				Name:    chk.Checker.Name(),
+				Type:    chk.Checker.Type(),
+				Address: chk.Checker.Address(),
+			},
+		})
+	}
+
+	return m
+}
+
+// Run probes every target at its interval until ctx is canceled.
+func (m *Monitor) Run(ctx context.Context, logger *slog.Logger) error {
+	if len(m.targets) == 0 {
+		return runner.ErrNoCheckers
+	}
+
+	var wg sync.WaitGroup
+	for _, t := range m.targets {
+		wg.Go(func() {
+			m.watch(ctx, t, logger)
+		})
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// Ready reports whether all targets are ready.
+func (m *Monitor) Ready() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, t := range m.targets {
+		if !t.status.Ready {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Statuses returns a snapshot of all target statuses in configuration order.
+func (m *Monitor) Statuses() []TargetStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	statuses := make([]TargetStatus, 0, len(m.targets))
+	for _, t := range m.targets {
+		statuses = append(statuses, t.status)
+	}
+
+	return statuses
+}
+
+// watch probes a single target until ctx is canceled.
+func (m *Monitor) watch(ctx context.Context, t *target, logger *slog.Logger) {
+	logger = logger.With(
+		slog.String("target", t.status.Name),
+		slog.String("type", t.status.Type),
+		slog.String("address", t.status.Address),
+	)
+
+	m.metrics.SetReady(t.status.Name, t.status.Type, false)
+	m.mu.Lock()
+	t.started = time.Now()
+	m.mu.Unlock()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		err := t.checker.Checker.Check(ctx)
+		if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+			return // Shutdown interrupted the check; do not record it as a failure.
+		}
+
+		failures := m.record(t, err, time.Now(), logger)
+
+		// Back off while failing and return to the base interval once the target recovers.
+		next, _ := wait.NextInterval(t.checker.Backoff, t.checker.Interval, max(failures, 1), t.checker.MaxInterval, err)
+		m.metrics.SetNextInterval(t.status.Name, t.status.Type, next)
+		timer.Reset(next)
+	}
+}
+
+// record applies a check result to the target state and returns the consecutive failure count.
+// Log messages are written after the state lock is released.
+func (m *Monitor) record(t *target, err error, now time.Time, logger *slog.Logger) int {
+	s, changed := m.update(t, err, now)
+
+	if err != nil {
+		logger.Warn(
+			fmt.Sprintf("%s check failed", s.Name),
+			slog.String("error", s.LastError),
+			slog.Int("consecutive_failures", s.ConsecutiveFailures),
+		)
+	}
+
+	switch {
+	case changed && s.Ready:
+		logger.Info(fmt.Sprintf("%s is ready ✓", s.Name), slog.Int("consecutive_successes", s.ConsecutiveSuccesses))
+	case changed:
+		logger.Warn(fmt.Sprintf("%s is not ready ✗", s.Name), slog.Int("consecutive_failures", s.ConsecutiveFailures))
+	}
+
+	return s.ConsecutiveFailures
+}
+
+// update applies a check result to the target state under the lock.
+// It returns a copy of the new state and whether the readiness changed.
+func (m *Monitor) update(t *target, err error, now time.Time) (TargetStatus, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := &t.status
+	s.Checks++
+	s.LastCheck = now
+
+	if err == nil {
+		s.ConsecutiveSuccesses++
+		s.ConsecutiveFailures = 0
+		s.LastError = ""
+		if !s.Ready && s.ConsecutiveSuccesses >= m.successThreshold {
+			if s.LastTransition.IsZero() {
+				m.metrics.SetTimeToReady(s.Name, s.Type, now.Sub(t.started))
+			}
+			s.Ready = true
+			s.LastTransition = now
+			m.metrics.SetReady(s.Name, s.Type, true)
+			return *s, true
+		}
+		return *s, false
+	}
+
+	s.ConsecutiveFailures++
+	s.ConsecutiveSuccesses = 0
+	s.LastError = err.Error()
+
+	if s.Ready && s.ConsecutiveFailures >= m.failureThreshold {
+		s.Ready = false
+		s.LastTransition = now
+		m.metrics.SetReady(s.Name, s.Type, false)
+		return *s, true
+	}
+
+	return *s, false
+}
@@ -0,0 +1,134 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/containeroo/never/internal/backoff"
+	"github.com/containeroo/never/internal/factory"
+	"github.com/containeroo/never/internal/runner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDown = errors.New("connection refused")
+
+// TestMonitorThresholds verifies readiness transitions honor success and failure thresholds.
+func TestMonitorThresholds(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	m := New(
+		[]factory.CheckerWithInterval{{Checker: &stubChecker{}}},
+		WithSuccessThreshold(2),
+		WithFailureThreshold(2),
+	)
+	tgt := m.targets[0]
+	now := time.Now()
+
+	m.record(tgt, nil, now, logger)
+	assert.False(t, m.Ready(), "one success is below the success threshold")
+
+	m.record(tgt, nil, now, logger)
+	assert.True(t, m.Ready())
+
+	m.record(tgt, errDown, now, logger)
+	assert.True(t, m.Ready(), "one failure is below the failure threshold")
+
+	m.record(tgt, errDown, now, logger)
+	assert.False(t, m.Ready())
+
+	status := m.Statuses()[0]
+	assert.Equal(t, 4, status.Checks)
+	assert.Equal(t, 2, status.ConsecutiveFailures)
+	assert.Equal(t, errDown.Error(), status.LastError)
+	assert.Equal(t, now, status.LastTransition)
+}
+
+// TestMonitorRecordLogsUnlocked verifies log handlers may read the monitor state.
+func TestMonitorRecordLogsUnlocked(t *testing.T) {
+	t.Parallel()
+
+	m := New([]factory.CheckerWithInterval{{Checker: &stubChecker{}}})
+	logger := slog.New(&readyHandler{Handler: slog.NewTextHandler(io.Discard, nil), monitor: m})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.record(m.targets[0], nil, time.Now(), logger)
+		m.record(m.targets[0], errDown, time.Now(), logger)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("record logged while holding the state lock")
+	}
+}
+
+// readyHandler reads the readiness of monitor for every log record.
+type readyHandler struct {
+	slog.Handler
+	monitor *Monitor
+}
+
+// Handle implements slog.Handler.
+func (h *readyHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.monitor.Ready()
+	return h.Handler.Handle(ctx, r)
+}
+
+// TestMonitorRun verifies Run keeps probing targets until the context is canceled.
+func TestMonitorRun(t *testing.T) {
+	t.Parallel()
+
+	chk := &stubChecker{}
+	m := New([]factory.CheckerWithInterval{{
+		Checker:  chk,
+		Interval: 5 * time.Millisecond,
+		Backoff:  backoff.ModeLinear,
+	}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- m.Run(ctx, slog.New(slog.NewTextHandler(io.Discard, nil))) }()
+
+	require.Eventually(t, func() bool { return chk.calls.Load() >= 3 }, 2*time.Second, 5*time.Millisecond)
+	assert.True(t, m.Ready())
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// TestMonitorRunNoCheckers verifies Run rejects an empty target list.
+func TestMonitorRunNoCheckers(t *testing.T) {
+	t.Parallel()
+
+	err := New(nil).Run(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	assert.ErrorIs(t, err, runner.ErrNoCheckers)
+}
+
+type stubChecker struct {
+	calls atomic.Int32
+	err   error
+}
+
+// Check performs the checker operation.
+func (c *stubChecker) Check(context.Context) error {
+	c.calls.Add(1)
+	return c.err
+}
+
+// Name returns the checker name.
+func (c *stubChecker) Name() string { return "stub" }
+
+// Type returns the checker type.
+func (c *stubChecker) Type() string { return "TCP" }
+
+// Address returns the checker address.
+func (c *stubChecker) Address() string { return "127.0.0.1:1" }
@@ -0,0 +1,217 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/containeroo/never/internal/backoff"
+	"github.com/containeroo/never/internal/checker"
+)
+
+// Outcome describes how a run ended.
+type Outcome string
+
+const (
+	// OutcomeReady means all targets became ready.
+	OutcomeReady Outcome = "ready"
+	// OutcomeFailed means at least one target failed or the run could not start.
+	OutcomeFailed Outcome = "failed"
+	// OutcomeInterrupted means the run was stopped by a signal or cancellation.
+	OutcomeInterrupted Outcome = "interrupted"
+)
+
+// State describes the final state of a target.
+type State string
+
+const (
+	// StatePending means the target was never checked.
+	StatePending State = "pending"
+	// StateReady means the target became ready.
+	StateReady State = "ready"
+	// StateFailed means the target exceeded its max attempts.
+	StateFailed State = "failed"
+	// StateDeadlineExceeded means the run deadline expired before the target became ready.
+	StateDeadlineExceeded State = "deadline_exceeded"
+	// StateCanceled means the run was canceled before the target became ready.
+	StateCanceled State = "canceled"
+)
+
+// Target is the report entry of one checker.
+type Target struct {
+	Name               string    `json:"name"`
+	Type               string    `json:"type"`
+	Address            string    `json:"address"`
+	Backoff            string    `json:"backoff"`
+	IntervalSeconds    float64   `json:"intervalSeconds"`
+	MaxIntervalSeconds float64   `json:"maxIntervalSeconds"`
+	MaxAttempts        int       `json:"maxAttempts"`
+	Attempts           int       `json:"attempts"`
+	LatenciesSeconds   []float64 `json:"latenciesSeconds"`
+	WaitSeconds        float64   `json:"waitSeconds"`
+	LastError          string    `json:"lastError,omitempty"`
+	State              State     `json:"state"`
+}
+
+// Report collects the result of a run. All methods are safe for concurrent use
+// and are no-ops on a nil Report.
+type Report struct {
+	mu sync.Mutex
+
+	StartedAt       time.Time `json:"startedAt"`
+	FinishedAt      time.Time `json:"finishedAt"`
+	DurationSeconds float64   `json:"durationSeconds"`
+	Outcome         Outcome   `json:"outcome"`
+	Error           string    `json:"error,omitempty"`
+	Cause           string    `json:"cause,omitempty"`
+	Targets         []*Target `json:"targets"`
+
+	byChecker map[checker.Checker]*Target
+}
+
+// New creates an empty Report started now.
+func New() *Report {
+	return &Report{
+		StartedAt: time.Now(),
+		Targets:   []*Target{},
+		byChecker: make(map[checker.Checker]*Target),
+	}
+}
+
+// Add registers a checker so it is reported even if it is never checked.
+func (r *Report) Add(c checker.Checker) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.target(c)
+}
+
+// Start registers a checker and the retry settings applied to it.
+func (r *Report) Start(c checker.Checker, interval time.Duration, maxAttempts int, mode backoff.Mode, maxInterval time.Duration) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t := r.target(c)
+	t.Backoff = mode.String()
+	t.IntervalSeconds = interval.Seconds()
+	t.MaxIntervalSeconds = maxInterval.Seconds()
+	t.MaxAttempts = maxAttempts
+}
+
+// Attempt records the latency and result of one check attempt.
+func (r *Report) Attempt(c checker.Checker, latency time.Duration, err error) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t := r.target(c)
+	t.Attempts++
+	t.LatenciesSeconds = append(t.LatenciesSeconds, latency.Seconds())
+	if err != nil {
+		t.LastError = err.Error()
+	}
+}
+
+// Finish records the final state of a checker and the total time spent waiting for it.
+func (r *Report) Finish(c checker.Checker, state State, waited time.Duration) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t := r.target(c)
+	t.State = state
+	t.WaitSeconds = waited.Seconds()
+}
+
+// Complete records the overall outcome, the run error and the context cause.
+func (r *Report) Complete(outcome Outcome, err, cause error) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.FinishedAt = time.Now()
+	r.DurationSeconds = r.FinishedAt.Sub(r.StartedAt).Seconds()
+	r.Outcome = outcome
+	if err != nil {
+		r.Error = err.Error()
+	}
+	if cause != nil {
+		r.Cause = cause.Error()
+	}
+}
+
+// WriteFile writes the report as indented JSON. The file is replaced atomically.
+func (r *Report) WriteFile(path string) error {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // nolint:errcheck
+
+	if err := tmp.Chmod(0o644); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+
+	return nil
+}
+
+// target returns the entry for c, creating it in registration order when missing.
+func (r *Report) target(c checker.Checker) *Target {
+	if t, ok := r.byChecker[c]; ok {
+		return t
+	}
+
+	t := &Target{
+		Name:             c.Name(),
+		Type:             c.Type(),
+		Address:          c.Address(),
+		LatenciesSeconds: []float64{},
+		State:            StatePending,
+	}
+	r.byChecker[c] = t
+	r.Targets = append(r.Targets, t)
+
+	return t
+}
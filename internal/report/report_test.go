@@ -0,0 +1,97 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containeroo/never/internal/backoff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReportWriteFile verifies the report is written as JSON with per-target details.
+func TestReportWriteFile(t *testing.T) {
+	t.Parallel()
+
+	first := &stubChecker{name: "db"}
+	second := &stubChecker{name: "api"}
+
+	r := New()
+	r.Add(first)
+	r.Add(second)
+
+	r.Start(first, time.Second, 3, backoff.ModeExponential, 4*time.Second)
+	r.Attempt(first, 100*time.Millisecond, errors.New("connection refused"))
+	r.Attempt(first, 200*time.Millisecond, errors.New("connection refused"))
+	r.Finish(first, StateFailed, 3*time.Second)
+	r.Complete(OutcomeFailed, errors.New("max attempts reached"), context.Canceled)
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, r.WriteFile(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var got struct {
+		Outcome string `json:"outcome"`
+		Error   string `json:"error"`
+		Cause   string `json:"cause"`
+		Targets []Target
+	}
+	require.NoError(t, json.Unmarshal(data, &got))
+
+	assert.Equal(t, "failed", got.Outcome)
+	assert.Equal(t, "max attempts reached", got.Error)
+	assert.Equal(t, "context canceled", got.Cause)
+	require.Len(t, got.Targets, 2)
+
+	db := got.Targets[0]
+	assert.Equal(t, "db", db.Name)
+	assert.Equal(t, "exponential", db.Backoff)
+	assert.Equal(t, 3, db.MaxAttempts)
+	assert.InDelta(t, 4.0, db.MaxIntervalSeconds, 0.0001)
+	assert.Equal(t, 2, db.Attempts)
+	assert.InDeltaSlice(t, []float64{0.1, 0.2}, db.LatenciesSeconds, 0.0001)
+	assert.InDelta(t, 3.0, db.WaitSeconds, 0.0001)
+	assert.Equal(t, "connection refused", db.LastError)
+	assert.Equal(t, StateFailed, db.State)
+
+	api := got.Targets[1]
+	assert.Equal(t, "api", api.Name)
+	assert.Equal(t, StatePending, api.State)
+	assert.Empty(t, api.LatenciesSeconds)
+}
+
+// TestReportNil verifies a nil report is a no-op.
+func TestReportNil(t *testing.T) {
+	t.Parallel()
+
+	var r *Report
+	r.Add(&stubChecker{name: "db"})
+	r.Complete(OutcomeReady, nil, nil)
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, r.WriteFile(path))
+	assert.NoFileExists(t, path)
+}
+
+type stubChecker struct {
+	name string
+}
+
+// Check performs the checker operation.
+func (c *stubChecker) Check(context.Context) error { return nil }
+
+// Name returns the checker name.
+func (c *stubChecker) Name() string { return c.name }
+
+// Type returns the checker type.
+func (c *stubChecker) Type() string { return "TCP" }
+
+// Address returns the checker address.
+func (c *stubChecker) Address() string { return "127.0.0.1:1" }
@@ -0,0 +1,119 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// TerminationMessageLimit is the maximum size Kubernetes reads from a termination message file.
+const TerminationMessageLimit int = 4096
+
+const (
+	ellipsis = "..."
+	// minErrorBytes keeps at least this much of each error when shortening lines.
+	minErrorBytes = 64
+)
+
+// TerminationMessage returns a concise summary of the targets that did not become ready.
+// The message never exceeds limit bytes: long errors are shortened first, then trailing
+// targets are summarized as a count.
+func (r *Report) TerminationMessage(limit int) string {
+	if r == nil {
+		return ""
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var notReady []*Target
+	for _, t := range r.Targets {
+		if t.State != StateReady {
+			notReady = append(notReady, t)
+		}
+	}
+
+	header := fmt.Sprintf("never %s: %d of %d targets not ready", r.Outcome, len(notReady), len(r.Targets))
+	if len(r.Targets) == 0 && r.Error != "" {
+		header = fmt.Sprintf("never %s: %s", r.Outcome, r.Error)
+	}
+	var footer string
+	if r.Cause != "" {
+		footer = "cause: " + r.Cause
+	}
+
+	prefixes := make([]string, 0, len(notReady))
+	errs := make([]string, 0, len(notReady))
+	for _, t := range notReady {
+		prefixes = append(prefixes, fmt.Sprintf("- %s (%s %s): %s after %d attempts", t.Name, t.Type, t.Address, t.State, t.Attempts))
+		errs = append(errs, t.LastError)
+	}
+
+	return truncate(buildMessage(header, footer, prefixes, errs, limit), limit)
+}
+
+// buildMessage joins the message parts, shortening errors and dropping targets until it fits limit.
+func buildMessage(header, footer string, prefixes, errs []string, limit int) string {
+	for shown := len(prefixes); shown >= 0; shown-- {
+		lines := []string{header}
+		fixed := len(header) + len(footer) + 2
+		if shown < len(prefixes) {
+			fixed += len(moreLine(len(prefixes)-shown)) + 1
+		}
+		for _, p := range prefixes[:shown] {
+			fixed += len(p) + 1
+		}
+
+		// Share the remaining space evenly between the shown errors.
+		errBudget := limit - fixed
+		if shown > 0 {
+			errBudget /= shown
+		}
+		if shown > 1 && errBudget < minErrorBytes {
+			continue
+		}
+
+		for i, p := range prefixes[:shown] {
+			if errs[i] == "" {
+				lines = append(lines, p)
+				continue
+			}
+			lines = append(lines, p+": "+truncate(errs[i], max(errBudget-2, 0)))
+		}
+		if shown < len(prefixes) {
+			lines = append(lines, moreLine(len(prefixes)-shown))
+		}
+		if footer != "" {
+			lines = append(lines, footer)
+		}
+
+		msg := strings.Join(lines, "\n")
+		if len(msg) <= limit {
+			return msg
+		}
+	}
+
+	return header
+}
+
+// moreLine summarizes targets that did not fit into the message.
+func moreLine(n int) string {
+	return fmt.Sprintf("... and %d more targets not ready", n)
+}
+
+// truncate shortens s to at most limit bytes on a rune boundary, marking the cut with an ellipsis.
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	if limit <= len(ellipsis) {
+		return ellipsis[:max(limit, 0)]
+	}
+
+	cut := limit - len(ellipsis)
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+
+	return s[:cut] + ellipsis
+}
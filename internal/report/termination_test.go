@@ -0,0 +1,79 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTerminationMessage verifies the message lists targets that did not become ready.
+func TestTerminationMessage(t *testing.T) {
+	t.Parallel()
+
+	db := &stubChecker{name: "db"}
+	api := &stubChecker{name: "api"}
+
+	r := New()
+	r.Add(db)
+	r.Add(api)
+	r.Attempt(db, time.Millisecond, errors.New("connection refused"))
+	r.Attempt(db, time.Millisecond, errors.New("connection refused"))
+	r.Finish(db, StateFailed, time.Second)
+	r.Attempt(api, time.Millisecond, nil)
+	r.Finish(api, StateReady, time.Second)
+	r.Complete(OutcomeFailed, errors.New("checker 'db' failed"), nil)
+
+	assert.Equal(t,
+		"never failed: 1 of 2 targets not ready\n"+
+			"- db (TCP 127.0.0.1:1): failed after 2 attempts: connection refused",
+		r.TerminationMessage(TerminationMessageLimit),
+	)
+}
+
+// TestTerminationMessageWithoutTargets verifies startup errors are reported when no target was built.
+func TestTerminationMessageWithoutTargets(t *testing.T) {
+	t.Parallel()
+
+	r := New()
+	r.Complete(OutcomeFailed, errors.New("failed to create ICMP checker"), nil)
+
+	assert.Equal(t, "never failed: failed to create ICMP checker", r.TerminationMessage(TerminationMessageLimit))
+}
+
+// TestTerminationMessageTruncation verifies long messages are shortened to the limit.
+func TestTerminationMessageTruncation(t *testing.T) {
+	t.Parallel()
+
+	r := New()
+	for i := range 200 {
+		c := &stubChecker{name: fmt.Sprintf("target-%03d", i)}
+		r.Add(c)
+		r.Attempt(c, time.Millisecond, errors.New(strings.Repeat("ü", 500)))
+		r.Finish(c, StateCanceled, time.Second)
+	}
+	r.Complete(OutcomeFailed, errors.New("boom"), errors.New("received signal terminated"))
+
+	msg := r.TerminationMessage(TerminationMessageLimit)
+
+	assert.LessOrEqual(t, len(msg), TerminationMessageLimit)
+	assert.True(t, utf8.ValidString(msg))
+	assert.True(t, strings.HasPrefix(msg, "never failed: 200 of 200 targets not ready\n- target-000"))
+	assert.Contains(t, msg, "more targets not ready")
+	assert.Contains(t, msg, "...")
+	assert.True(t, strings.HasSuffix(msg, "\ncause: received signal terminated"))
+}
+
+// TestTruncate verifies truncation respects rune boundaries.
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "short", truncate("short", 10))
+	assert.Equal(t, "abc...", truncate("abcdefghij", 6))
+	assert.Equal(t, "ü...", truncate("üüüü", 6))
+	assert.Equal(t, "..", truncate("abcdef", 2))
+}
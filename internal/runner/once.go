@@ -0,0 +1,53 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/containeroo/never/internal/checker"
+	"github.com/containeroo/never/internal/factory"
+)
+
+// ErrNotReady is returned by CheckOnce when at least one target failed its check.
+var ErrNotReady = errors.New("not all targets are ready")
+
+// Result is the outcome of a single check of one target.
+type Result struct {
+	Checker  checker.Checker
+	Duration time.Duration
+	Err      error
+}
+
+// CheckOnce runs the check of every target exactly once and concurrently, without retries or backoff.
+// Results are returned in the order of checkers. The error wraps ErrNotReady when any check failed.
+func CheckOnce(ctx context.Context, checkers []factory.CheckerWithInterval) ([]Result, error) {
+	if len(checkers) == 0 {
+		return nil, ErrNoCheckers
+	}
+
+	results := make([]Result, len(checkers))
+	var wg sync.WaitGroup
+	for i, chk := range checkers {
+		wg.Go(func() {
+			start := time.Now()
+			err := chk.Checker.Check(ctx)
+			results[i] = Result{Checker: chk.Checker, Duration: time.Since(start), Err: err}
+		})
+	}
+	wg.Wait()
+
+	var failed int
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("%w: %d of %d targets failed", ErrNotReady, failed, len(results))
+	}
+
+	return results, nil
+}
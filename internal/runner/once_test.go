@@ -0,0 +1,73 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/containeroo/never/internal/factory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// onceChecker is a checker returning a fixed error and counting its calls.
+type onceChecker struct {
+	name  string
+	err   error
+	calls atomic.Int32
+}
+
+func (c *onceChecker) Check(context.Context) error {
+	c.calls.Add(1)
+	return c.err
+}
+func (c *onceChecker) Name() string    { return c.name }
+func (c *onceChecker) Type() string    { return "TCP" }
+func (c *onceChecker) Address() string { return c.name + ":80" }
+
+// TestCheckOnce verifies every target is checked exactly once and failures are reported.
+func TestCheckOnce(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all ready", func(t *testing.T) {
+		t.Parallel()
+
+		a, b := &onceChecker{name: "a"}, &onceChecker{name: "b"}
+		results, err := CheckOnce(context.Background(), []factory.CheckerWithInterval{
+			{Checker: a, Interval: time.Hour},
+			{Checker: b, Interval: time.Hour},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, a, results[0].Checker)
+		assert.Equal(t, b, results[1].Checker)
+		assert.Equal(t, int32(1), a.calls.Load())
+		assert.Equal(t, int32(1), b.calls.Load())
+	})
+
+	t.Run("one failed", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("boom")
+		down := &onceChecker{name: "down", err: boom}
+		results, err := CheckOnce(context.Background(), []factory.CheckerWithInterval{
+			{Checker: &onceChecker{name: "up"}},
+			{Checker: down, MaxAttempts: 5},
+		})
+		require.ErrorIs(t, err, ErrNotReady)
+		assert.EqualError(t, err, "not all targets are ready: 1 of 2 targets failed")
+		require.Len(t, results, 2)
+		assert.NoError(t, results[0].Err)
+		assert.ErrorIs(t, results[1].Err, boom)
+		assert.Equal(t, int32(1), down.calls.Load())
+	})
+
+	t.Run("no checkers", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := CheckOnce(context.Background(), nil)
+		require.ErrorIs(t, err, ErrNoCheckers)
+	})
+}
@@ -16,7 +16,8 @@ import (
 var ErrNoCheckers = errors.New("no checkers to run")
 
 // RunAll runs all checkers concurrently and returns the first error or context cancellation.
-func RunAll(ctx context.Context, checkers []factory.CheckerWithInterval, maxAttempts int, logger *slog.Logger) error {
+// opts are applied to every target after its own backoff settings.
+func RunAll(ctx context.Context, checkers []factory.CheckerWithInterval, maxAttempts int, logger *slog.Logger, opts ...wait.Option) error {
 	if len(checkers) == 0 {
 		return ErrNoCheckers
 	}
@@ -28,14 +29,17 @@ func RunAll(ctx context.Context, checkers []factory.CheckerWithInterval, maxAtte
 		name := chk.Checker.Name() // avoid re-calling in error path
 		eg.Go(func() error {
 			attempts := utils.DefaultIfZero(checker.MaxAttempts, maxAttempts)
+			waitOpts := append([]wait.Option{
+				wait.WithBackoff(checker.Backoff),
+				wait.WithMaxInterval(checker.MaxInterval),
+			}, opts...)
 			err := wait.WaitUntilReady(
 				ctx,
 				checker.Interval,
 				attempts,
 				checker.Checker,
 				logger,
-				wait.WithBackoff(checker.Backoff),
-				wait.WithMaxInterval(checker.MaxInterval),
+				waitOpts...,
 			)
 			if err != nil {
 				return fmt.Errorf("checker '%s' failed: %w", name, err)
@@ -10,6 +10,7 @@ import (
 type MockProtocol struct {
 	MakeRequestFunc   func(identifier, sequence uint16) ([]byte, error)
 	ValidateReplyFunc func(reply []byte, identifier, sequence uint16) error
+	ParseReplyFunc    func(reply []byte) (identifier, sequence uint16, err error)
 	NetworkFunc       func() string
 	ListenPacketFunc  func(ctx context.Context, network, address string) (net.PacketConn, error)
 }
@@ -30,6 +31,14 @@ func (m *MockProtocol) ValidateReply(reply []byte, identifier, sequence uint16)
 	return nil
 }
 
+// ParseReply is a mock implementation of the Protocol.ParseReply method.
+func (m *MockProtocol) ParseReply(reply []byte) (identifier, sequence uint16, err error) {
+	if m.ParseReplyFunc != nil {
+		return m.ParseReplyFunc(reply)
+	}
+	return 0, 0, nil
+}
+
 // Network is a mock implementation of the Protocol.Network method.
 func (m *MockProtocol) Network() string {
 	if m.NetworkFunc != nil {
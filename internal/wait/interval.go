@@ -0,0 +1,32 @@
+package wait
+
+import (
+	"time"
+
+	"github.com/containeroo/never/internal/backoff"
+	"github.com/containeroo/never/internal/checker"
+)
+
+// IntervalSource describes where the delay before the next attempt came from.
+type IntervalSource string
+
+const (
+	// IntervalSourceBackoff means the delay was calculated by the backoff mode.
+	IntervalSourceBackoff IntervalSource = "backoff"
+	// IntervalSourceRetryAfter means the target asked for the delay, e.g. with a Retry-After header.
+	IntervalSourceRetryAfter IntervalSource = "retry-after"
+)
+
+// NextInterval returns the delay before the next attempt after a failed check.
+// A delay suggested by the target through err takes precedence over the backoff
+// and is capped by maxInterval when greater than zero.
+func NextInterval(mode backoff.Mode, base time.Duration, attempt int, maxInterval time.Duration, err error) (time.Duration, IntervalSource) {
+	if delay, ok := checker.RetryAfter(err); ok {
+		if maxInterval > 0 {
+			delay = min(delay, maxInterval)
+		}
+		return delay, IntervalSourceRetryAfter
+	}
+
+	return backoff.NextInterval(mode, base, attempt, maxInterval), IntervalSourceBackoff
+}
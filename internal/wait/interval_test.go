@@ -0,0 +1,40 @@
+package wait
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/containeroo/never/internal/backoff"
+	"github.com/containeroo/never/internal/checker"
+)
+
+// TestNextInterval verifies server-suggested delays take precedence over backoff and respect the cap.
+func TestNextInterval(t *testing.T) {
+	t.Parallel()
+
+	retryAfter := &checker.RetryAfterError{Delay: 30 * time.Second, Err: errors.New("unavailable")}
+
+	tests := []struct {
+		name        string
+		maxInterval time.Duration
+		err         error
+		want        time.Duration
+		wantSource  IntervalSource
+	}{
+		{name: "backoff", err: errors.New("refused"), want: 4 * time.Second, wantSource: IntervalSourceBackoff},
+		{name: "retry after", err: retryAfter, want: 30 * time.Second, wantSource: IntervalSourceRetryAfter},
+		{name: "retry after capped", maxInterval: 10 * time.Second, err: retryAfter, want: 10 * time.Second, wantSource: IntervalSourceRetryAfter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, source := NextInterval(backoff.ModeExponential, time.Second, 3, tt.maxInterval, tt.err)
+			if got != tt.want || source != tt.wantSource {
+				t.Errorf("NextInterval() = %s, %s; want %s, %s", got, source, tt.want, tt.wantSource)
+			}
+		})
+	}
+}
@@ -9,14 +9,21 @@ import (
 
 	"github.com/containeroo/never/internal/backoff"
 	"github.com/containeroo/never/internal/checker"
+	"github.com/containeroo/never/internal/metrics"
+	"github.com/containeroo/never/internal/report"
 )
 
 // ErrMaxAttemptsExceeded is returned when the maximum number of attempts is reached.
 var ErrMaxAttemptsExceeded = errors.New("max attempts reached")
 
+// ErrPermanentFailure is returned when a check fails with an error that retrying cannot fix.
+var ErrPermanentFailure = errors.New("permanent failure")
+
 type options struct {
 	backoffMode backoff.Mode
 	maxInterval time.Duration
+	metrics     *metrics.Registry
+	report      *report.Report
 }
 
 // Option configures WaitUntilReady behavior.
@@ -41,12 +48,26 @@ func WithMaxInterval(maxInterval time.Duration) Option {
 	}
 }
 
+// WithMetrics records readiness, time to ready and the next interval in the given registry.
+func WithMetrics(registry *metrics.Registry) Option {
+	return func(o *options) {
+		o.metrics = registry
+	}
+}
+
+// WithReport records attempts and the final state in the given report.
+func WithReport(r *report.Report) Option {
+	return func(o *options) {
+		o.report = r
+	}
+}
+
 // WaitUntilReady continuously attempts to connect to the specified target until it becomes available or the context is canceled.
 func WaitUntilReady(
 	ctx context.Context,
 	interval time.Duration,
 	maxAttempts int,
-	checker checker.Checker,
+	chk checker.Checker,
 	logger *slog.Logger,
 	opts ...Option,
 ) error {
@@ -56,46 +77,76 @@ func WaitUntilReady(
 	}
 
 	logger = logger.With(
-		slog.String("target", checker.Name()),
-		slog.String("type", checker.Type()),
-		slog.String("address", checker.Address()),
+		slog.String("target", chk.Name()),
+		slog.String("type", chk.Type()),
+		slog.String("address", chk.Address()),
 		slog.Duration("interval", interval),
 		slog.Int("max_attempts", maxAttempts),
 		slog.String("backoff", cfg.backoffMode.String()),
 		slog.Duration("max_interval", cfg.maxInterval),
 	)
 
-	logger.Info(fmt.Sprintf("Waiting for %s to become ready...", checker.Name()))
+	logger.Info(fmt.Sprintf("Waiting for %s to become ready...", chk.Name()))
 
 	timer := newStoppedTimer(interval)
 	defer timer.Stop()
 
+	cfg.metrics.SetReady(chk.Name(), chk.Type(), false)
+	cfg.report.Start(chk, interval, maxAttempts, cfg.backoffMode, cfg.maxInterval)
+
 	attempt := 0
+	start := time.Now()
+	state := report.StateCanceled
+	defer func() { cfg.report.Finish(chk, state, time.Since(start)) }()
 
 	for {
 		attempt++
-		err := checker.Check(ctx)
-		if err == nil {
-			logger.Info(fmt.Sprintf("%s is ready ✓", checker.Name()), slog.Int("attempt", attempt))
-			return nil // Successfully connected to the target
-		}
+		checkStart := time.Now()
+		err := chk.Check(ctx)
 		if errors.Is(err, context.Canceled) {
 			return nil // Treat cancellation during a check as expected shutdown.
 		}
+		latency := time.Since(checkStart)
+		cfg.report.Attempt(chk, latency, err)
+
+		if err == nil {
+			state = report.StateReady
+			cfg.metrics.SetReady(chk.Name(), chk.Type(), true)
+			cfg.metrics.SetTimeToReady(chk.Name(), chk.Type(), time.Since(start))
+			attrs := append([]any{slog.Int("attempt", attempt), slog.Duration("latency", latency)}, statsAttrs(chk)...)
+			logger.Info(fmt.Sprintf("%s is ready ✓", chk.Name()), attrs...)
+			return nil // Successfully connected to the target
+		}
 		if errors.Is(err, context.DeadlineExceeded) {
+			state = report.StateDeadlineExceeded
 			return err
 		}
 
-		waitInterval := backoff.NextInterval(cfg.backoffMode, interval, attempt, cfg.maxInterval)
+		if checker.IsPermanent(err) {
+			state = report.StateFailed
+			logger.Error(
+				fmt.Sprintf("%s failed permanently, giving up ✗", chk.Name()),
+				slog.String("error", err.Error()),
+				slog.Int("attempt", attempt),
+				slog.Duration("latency", latency),
+			)
+			return fmt.Errorf("%w after %d attempts: %w", ErrPermanentFailure, attempt, err)
+		}
+
+		waitInterval, source := NextInterval(cfg.backoffMode, interval, attempt, cfg.maxInterval, err)
+		cfg.metrics.SetNextInterval(chk.Name(), chk.Type(), waitInterval)
 
 		logger.Warn(
-			fmt.Sprintf("%s is not ready ✗", checker.Name()),
+			fmt.Sprintf("%s is not ready ✗", chk.Name()),
 			slog.String("error", err.Error()),
 			slog.Int("attempt", attempt),
+			slog.Duration("latency", latency),
 			slog.Duration("next_interval", waitInterval),
+			slog.String("next_interval_source", string(source)),
 		)
 
 		if maxAttempts > 0 && attempt >= maxAttempts {
+			state = report.StateFailed
 			return fmt.Errorf("%w after %d attempts: %w", ErrMaxAttemptsExceeded, attempt, err)
 		}
 
@@ -108,6 +159,7 @@ func WaitUntilReady(
 			if ctx.Err() == context.Canceled {
 				return nil // Treat context cancellation as expected behavior
 			}
+			state = report.StateDeadlineExceeded
 			return ctx.Err()
 		}
 	}
@@ -121,3 +173,13 @@ func newStoppedTimer(d time.Duration) *time.Timer {
 	}
 	return timer
 }
+
+// statsAttrs returns the statistics of the last attempt as log attributes, if the checker collects any.
+func statsAttrs(c checker.Checker) []any {
+	stats, ok := checker.LastStats(c)
+	if !ok {
+		return nil
+	}
+
+	return []any{slog.Any("stats", stats)}
+}
@@ -8,10 +8,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/containeroo/never/internal/checker"
+	"github.com/containeroo/never/internal/metrics"
 	"github.com/containeroo/never/internal/testutils"
 )
 
@@ -274,6 +276,41 @@ func TestWaitUntilReady_ContextCanceledDuringCheckStopsGracefully(t *testing.T)
 	}
 }
 
+// TestWaitUntilReady_RecordsMetrics verifies attempts of an instrumented checker and readiness are recorded in the registry.
+func TestWaitUntilReady_RecordsMetrics(t *testing.T) {
+	t.Parallel()
+
+	var output strings.Builder
+	logger := slog.New(slog.NewTextHandler(&output, nil))
+	registry := metrics.NewRegistry()
+
+	err := WaitUntilReady(
+		context.Background(),
+		10*time.Millisecond,
+		2,
+		registry.Instrument(staticErrorChecker{err: errors.New("boom")}),
+		logger,
+		WithMetrics(registry),
+	)
+	if !errors.Is(err, ErrMaxAttemptsExceeded) {
+		t.Fatalf("Expected ErrMaxAttemptsExceeded, got %v", err)
+	}
+
+	text := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(text, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	for _, expected := range []string{
+		`never_check_attempts_total{target="CanceledServer",type="TCP"} 2`,
+		`never_check_failures_total{reason="other",target="CanceledServer",type="TCP"} 2`,
+		`never_target_ready{target="CanceledServer",type="TCP"} 0`,
+		`never_check_next_interval_seconds{target="CanceledServer",type="TCP"} 0.01`,
+	} {
+		if !strings.Contains(text.Body.String(), expected) {
+			t.Errorf("Expected metrics to contain %q, got %q", expected, text.Body.String())
+		}
+	}
+}
+
 type staticErrorChecker struct {
 	err error
 }
@@ -289,3 +326,143 @@ func (c staticErrorChecker) Type() string { return "TCP" }
 
 // Address returns the checker address.
 func (c staticErrorChecker) Address() string { return testutils.LocalhostAddr("1") }
+
+// TestWaitUntilReady_HonoursRetryAfter ensures a Retry-After delay replaces the configured interval.
+func TestWaitUntilReady_HonoursRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	checker, err := checker.NewChecker(checker.HTTP, httpServerName, server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create HTTPChecker: %v", err)
+	}
+
+	var output strings.Builder
+	logger := slog.New(slog.NewTextHandler(&output, nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err = WaitUntilReady(ctx, time.Hour, -1, checker, logger)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, want := range []string{"next_interval=1s", "next_interval_source=retry-after", "HTTPServer is ready ✓"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("Expected log to contain %q, got %q", want, output.String())
+		}
+	}
+}
+
+// TestWaitUntilReady_PermanentFailure ensures a permanent error stops retrying immediately.
+func TestWaitUntilReady_PermanentFailure(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	chk, err := checker.NewChecker(checker.HTTP, httpServerName, server.URL, checker.WithFailFastStatusCodes([]int{http.StatusForbidden}))
+	if err != nil {
+		t.Fatalf("Failed to create HTTPChecker: %v", err)
+	}
+
+	var output strings.Builder
+	logger := slog.New(slog.NewTextHandler(&output, nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	err = WaitUntilReady(ctx, 10*time.Millisecond, -1, chk, logger)
+	if !errors.Is(err, ErrPermanentFailure) {
+		t.Fatalf("Expected ErrPermanentFailure, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Expected exactly 1 attempt, got %d", got)
+	}
+
+	expectedLog := "HTTPServer failed permanently, giving up ✗"
+	if !strings.Contains(output.String(), expectedLog) {
+		t.Errorf("Expected log to contain %q, got %q", expectedLog, output.String())
+	}
+}
+
+// TestWaitUntilReady_MaxLatency ensures slow successful checks are retried and latency is logged.
+func TestWaitUntilReady_MaxLatency(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	chk, err := checker.NewChecker(checker.HTTP, httpServerName, server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create HTTPChecker: %v", err)
+	}
+	slow := checker.NewLatencyChecker(chk, 100*time.Millisecond, 100, 1)
+
+	var output strings.Builder
+	logger := slog.New(slog.NewTextHandler(&output, nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	err = WaitUntilReady(ctx, 10*time.Millisecond, -1, slow, logger)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, want := range []string{"exceeds max latency 100ms", "HTTPServer is ready ✓", "latency="} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("Expected log to contain %q, got %q", want, output.String())
+		}
+	}
+}
+
+// statsChecker is a ready checker that reports statistics of its last attempt.
+type statsChecker struct {
+	staticErrorChecker
+}
+
+// LastStats returns fixed statistics.
+func (c statsChecker) LastStats() (slog.Value, bool) {
+	return checker.ICMPStats{Sent: 3, Received: 3}.LogValue(), true
+}
+
+// TestWaitUntilReady_LogsStats ensures statistics collected by a checker are logged when it is ready.
+func TestWaitUntilReady_LogsStats(t *testing.T) {
+	t.Parallel()
+
+	var output strings.Builder
+	logger := slog.New(slog.NewTextHandler(&output, nil))
+
+	err := WaitUntilReady(context.Background(), 10*time.Millisecond, -1, statsChecker{}, logger)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, want := range []string{"stats.sent=3", "stats.received=3", "stats.loss_percent=0"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("Expected log to contain %q, got %q", want, output.String())
+		}
+	}
+}